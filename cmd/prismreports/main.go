@@ -0,0 +1,59 @@
+// Command prismreports is the self-hostable aggregator for prism's opt-in
+// benchmark telemetry: it accepts reportSubmission POSTs from the TUI,
+// stores them in SQLite, and rolls them up nightly into per-day
+// per-version aggregates that GET /summary serves back for the TUI's
+// Community Benchmarks panel.
+package main
+
+import (
+	"database/sql"
+	"flag"
+	"log"
+	"net/http"
+	"time"
+
+	_ "modernc.org/sqlite"
+)
+
+func main() {
+	addr := flag.String("addr", ":8585", "address to listen on")
+	dbPath := flag.String("db", "prismreports.db", "path to the sqlite database file")
+	retention := flag.Duration("retention", 90*24*time.Hour, "how long raw reports are kept before being purged (aggregates are kept forever)")
+	flag.Parse()
+
+	db, err := sql.Open("sqlite", *dbPath)
+	if err != nil {
+		log.Fatalf("prismreports: opening database: %v", err)
+	}
+	defer db.Close()
+
+	st, err := newStore(db)
+	if err != nil {
+		log.Fatalf("prismreports: %v", err)
+	}
+
+	go runNightlyRollup(st, *retention)
+
+	srv := newServer(st)
+	log.Printf("prismreports: listening on %s (db=%s)", *addr, *dbPath)
+	log.Fatal(http.ListenAndServe(*addr, srv.routes()))
+}
+
+// runNightlyRollup rolls up yesterday's reports once every 24h, starting
+// from the first tick rather than on launch - a freshly started server
+// shouldn't immediately rewrite aggregates a previous instance already
+// computed correctly for today.
+func runNightlyRollup(st *store, retention time.Duration) {
+	ticker := time.NewTicker(24 * time.Hour)
+	defer ticker.Stop()
+	for range ticker.C {
+		yesterday := time.Now().UTC().AddDate(0, 0, -1).Format("2006-01-02")
+		if err := st.rollupDay(yesterday); err != nil {
+			log.Printf("prismreports: rollup for %s failed: %v", yesterday, err)
+			continue
+		}
+		if err := st.purgeOlderThan(time.Now().UTC().Add(-retention)); err != nil {
+			log.Printf("prismreports: purge failed: %v", err)
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+)
+
+// server wires store to the two routes a prismreports instance exposes:
+// POST /reports (ingest) and GET /summary (read back an aggregate).
+type server struct {
+	store *store
+}
+
+func newServer(s *store) *server {
+	return &server{store: s}
+}
+
+func (srv *server) routes() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/reports", srv.handleReports)
+	mux.HandleFunc("/summary", srv.handleSummary)
+	return mux
+}
+
+// handleReports accepts one reportSubmission per POST. It never echoes back
+// anything about the submission itself - the response is just a status
+// code, so a client can't use it to fingerprint what the server stored.
+func (srv *server) handleReports(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	var sub reportSubmission
+	if err := json.NewDecoder(r.Body).Decode(&sub); err != nil {
+		http.Error(w, fmt.Sprintf("decoding submission: %v", err), http.StatusBadRequest)
+		return
+	}
+	if err := srv.store.insertReport(sub); err != nil {
+		log.Printf("prismreports: insert failed: %v", err)
+		http.Error(w, "storing report failed", http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusAccepted)
+}
+
+// handleSummary serves the latest rolled-up day's aggregate for
+// ?metric=<name>, optionally scoped to ?device_class=<class>, the shape
+// frontend/telemetry.go's fetchCommunitySummaryCmd expects back.
+func (srv *server) handleSummary(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	metricName := r.URL.Query().Get("metric")
+	if metricName == "" {
+		http.Error(w, "missing metric query param", http.StatusBadRequest)
+		return
+	}
+	deviceClass := r.URL.Query().Get("device_class")
+	summary, err := srv.store.summary(metricName, deviceClass)
+	if err != nil {
+		log.Printf("prismreports: summary query failed: %v", err)
+		http.Error(w, "summary query failed", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(summary)
+}
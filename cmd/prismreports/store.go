@@ -0,0 +1,252 @@
+package main
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// schema creates the raw reports table and the nightly rollup table if they
+// don't already exist. Both are keyed so a rollup run is idempotent: it can
+// be re-run after a crash without double-counting a day it already summed.
+const schema = `
+CREATE TABLE IF NOT EXISTS reports (
+	id            INTEGER PRIMARY KEY AUTOINCREMENT,
+	received_at   TIMESTAMP NOT NULL,
+	timestamp     TEXT NOT NULL,
+	device        TEXT NOT NULL,
+	os            TEXT NOT NULL,
+	prism_version TEXT NOT NULL,
+	metrics       TEXT NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS report_aggregates (
+	day           TEXT NOT NULL,
+	device_class  TEXT NOT NULL,
+	prism_version TEXT NOT NULL,
+	metric        TEXT NOT NULL,
+	unit          TEXT NOT NULL,
+	sample_count  INTEGER NOT NULL,
+	median        REAL NOT NULL,
+	p95           REAL NOT NULL,
+	PRIMARY KEY (day, device_class, prism_version, metric)
+);
+`
+
+// metric mirrors reportSubmission's submissionMetric on the TUI side; kept
+// as a separate type here since this package has no dependency on
+// frontend at all.
+type metric struct {
+	Name  string  `json:"name"`
+	Value float64 `json:"value"`
+	Unit  string  `json:"unit"`
+}
+
+// reportSubmission is the JSON body POSTed to /reports - see
+// frontend/telemetry.go's reportSubmission for the matching client shape.
+type reportSubmission struct {
+	Timestamp       string   `json:"timestamp"`
+	Device          string   `json:"device"`
+	Os              string   `json:"os"`
+	PrismVersion    string   `json:"prism_version"`
+	IndexingMetrics []metric `json:"indexing_metrics"`
+	SearchMetrics   []metric `json:"search_metrics"`
+	SystemMetrics   []metric `json:"system_metrics"`
+}
+
+// store wraps the database/sql handle every handler needs. It's deliberately
+// thin - no ORM, matching the rest of this repo's preference for the
+// standard library over a dependency where one isn't already pulled in.
+type store struct {
+	db *sql.DB
+}
+
+func newStore(db *sql.DB) (*store, error) {
+	if _, err := db.Exec(schema); err != nil {
+		return nil, fmt.Errorf("applying schema: %w", err)
+	}
+	return &store{db: db}, nil
+}
+
+// insertReport stores sub as one raw reports row, with all three metric
+// slices flattened into a single JSON column - the rollup query below is
+// the only thing that ever needs to parse them back out.
+func (s *store) insertReport(sub reportSubmission) error {
+	all := append(append(append([]metric{}, sub.IndexingMetrics...), sub.SearchMetrics...), sub.SystemMetrics...)
+	data, err := json.Marshal(all)
+	if err != nil {
+		return fmt.Errorf("encoding metrics: %w", err)
+	}
+	_, err = s.db.Exec(
+		`INSERT INTO reports (received_at, timestamp, device, os, prism_version, metrics) VALUES (?, ?, ?, ?, ?, ?)`,
+		time.Now().UTC(), sub.Timestamp, sub.Device, sub.Os, sub.PrismVersion, string(data),
+	)
+	return err
+}
+
+// rollupKey groups one day's samples by device class, prism_version, and
+// metric name - the same three dimensions report_aggregates is keyed on.
+type rollupKey struct {
+	deviceClass string
+	version     string
+	metric      string
+}
+
+// rollupDay aggregates every raw report received on day (UTC, "2006-01-02")
+// into report_aggregates, one row per (device class, version, metric)
+// triple. The device field a client submits is used as-is for device
+// class - reports has no finer-grained taxonomy to bucket it into. It's
+// safe to re-run for the same day - the INSERT OR REPLACE keyed on the
+// table's primary key overwrites any prior rollup for that day rather than
+// double-counting it.
+func (s *store) rollupDay(day string) error {
+	rows, err := s.db.Query(
+		`SELECT device, prism_version, metrics FROM reports WHERE date(received_at) = ?`, day,
+	)
+	if err != nil {
+		return fmt.Errorf("querying reports for %s: %w", day, err)
+	}
+	defer rows.Close()
+
+	// samples[key] -> all values seen that day, plus the unit of the first
+	// one (units don't vary per metric in practice).
+	samples := map[rollupKey][]float64{}
+	units := map[rollupKey]string{}
+	for rows.Next() {
+		var device, version, metricsJSON string
+		if err := rows.Scan(&device, &version, &metricsJSON); err != nil {
+			return fmt.Errorf("scanning report row: %w", err)
+		}
+		var ms []metric
+		if err := json.Unmarshal([]byte(metricsJSON), &ms); err != nil {
+			continue
+		}
+		for _, m := range ms {
+			key := rollupKey{deviceClass: device, version: version, metric: m.Name}
+			samples[key] = append(samples[key], m.Value)
+			if _, ok := units[key]; !ok {
+				units[key] = m.Unit
+			}
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	for key, values := range samples {
+		median, p95 := percentiles(values)
+		_, err := s.db.Exec(
+			`INSERT OR REPLACE INTO report_aggregates (day, device_class, prism_version, metric, unit, sample_count, median, p95) VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
+			day, key.deviceClass, key.version, key.metric, units[key], len(values), median, p95,
+		)
+		if err != nil {
+			return fmt.Errorf("writing aggregate for %s/%s/%s: %w", key.deviceClass, key.version, key.metric, err)
+		}
+	}
+	return nil
+}
+
+// purgeOlderThan deletes raw reports received before the retention cutoff -
+// report_aggregates already holds everything downstream consumers need, so
+// the raw rows are safe to drop once they've been rolled up.
+func (s *store) purgeOlderThan(cutoff time.Time) error {
+	_, err := s.db.Exec(`DELETE FROM reports WHERE received_at < ?`, cutoff)
+	return err
+}
+
+// summary returns the most recent day's aggregate for metric across all
+// versions, combined via a sample-weighted average of the per-version
+// medians/p95s - good enough for the TUI's community-comparison panel
+// without a caller having to know which prism_version rows exist. When
+// deviceClass is non-empty, it's further restricted to that device class,
+// so the TUI can compare against the median for a user's own kind of
+// machine instead of every device pooled together; an empty deviceClass
+// keeps the old all-devices behavior.
+func (s *store) summary(metricName, deviceClass string) (CommunitySummary, error) {
+	deviceFilter := ""
+	args := []any{metricName}
+	maxDayArgs := []any{metricName}
+	if deviceClass != "" {
+		deviceFilter = "AND device_class = ?"
+		args = append(args, deviceClass)
+		maxDayArgs = append(maxDayArgs, deviceClass)
+	}
+	args = append(args, maxDayArgs...)
+
+	query := `SELECT unit, sample_count, median, p95 FROM report_aggregates
+		 WHERE metric = ? ` + deviceFilter + `
+		 AND day = (SELECT MAX(day) FROM report_aggregates WHERE metric = ? ` + deviceFilter + `)`
+
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return CommunitySummary{}, err
+	}
+	defer rows.Close()
+
+	var unit string
+	var totalSamples int
+	var weightedMedian, weightedP95 float64
+	for rows.Next() {
+		var count int
+		var median, p95 float64
+		if err := rows.Scan(&unit, &count, &median, &p95); err != nil {
+			return CommunitySummary{}, err
+		}
+		totalSamples += count
+		weightedMedian += median * float64(count)
+		weightedP95 += p95 * float64(count)
+	}
+	if err := rows.Err(); err != nil {
+		return CommunitySummary{}, err
+	}
+	if totalSamples == 0 {
+		return CommunitySummary{Metric: metricName, DeviceClass: deviceClass}, nil
+	}
+	return CommunitySummary{
+		Metric:      metricName,
+		DeviceClass: deviceClass,
+		SampleCount: totalSamples,
+		Median:      weightedMedian / float64(totalSamples),
+		P95:         weightedP95 / float64(totalSamples),
+		Unit:        unit,
+	}, nil
+}
+
+// CommunitySummary is the JSON shape served from GET /summary - kept in
+// sync with frontend/telemetry.go's CommunitySummary by hand, same as
+// reportSubmission above. DeviceClass echoes back the query's device_class
+// filter (empty if the request didn't scope to one).
+type CommunitySummary struct {
+	Metric      string  `json:"metric"`
+	DeviceClass string  `json:"device_class,omitempty"`
+	SampleCount int     `json:"sample_count"`
+	Median      float64 `json:"median"`
+	P95         float64 `json:"p95"`
+	Unit        string  `json:"unit"`
+}
+
+// percentiles returns the median (sorted[n/2], the upper middle element for
+// an even n) and p95 (sorted[floor(0.95*(n-1))]) of a sorted copy of values
+// - fine for the sample sizes a self-hosted aggregator sees, no need for a
+// streaming/interpolated estimator.
+func percentiles(values []float64) (median, p95 float64) {
+	if len(values) == 0 {
+		return 0, 0
+	}
+	sorted := append([]float64{}, values...)
+	sort.Float64s(sorted)
+	return sorted[len(sorted)/2], sorted[rankIndex(len(sorted), 0.95)]
+}
+
+func rankIndex(n int, pct float64) int {
+	idx := int(pct * float64(n-1))
+	if idx < 0 {
+		return 0
+	}
+	if idx >= n {
+		return n - 1
+	}
+	return idx
+}
@@ -0,0 +1,181 @@
+package main
+
+import (
+	"math"
+	"os"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	colorful "github.com/lucasb-eyer/go-colorful"
+	"github.com/muesli/termenv"
+)
+
+// bannerTickMsg drives one animation frame of an AnimatedBanner. It's a
+// distinct type from the model's own message types so the tickers can't be
+// confused in Update's type switch.
+type bannerTickMsg time.Time
+
+// Option configures an AnimatedBanner built via NewAnimatedBanner.
+type Option func(*AnimatedBanner)
+
+// WithFPS sets how many frames per second the banner re-renders at.
+func WithFPS(fps int) Option {
+	return func(b *AnimatedBanner) {
+		if fps > 0 {
+			b.fps = fps
+		}
+	}
+}
+
+// WithDirection sets the blend direction (horizontal/vertical/diagonal).
+func WithDirection(dir GradientDirection) Option {
+	return func(b *AnimatedBanner) { b.direction = dir }
+}
+
+// WithPalette overrides the default theme gradient with explicit stops.
+func WithPalette(stops ...colorful.Color) Option {
+	return func(b *AnimatedBanner) {
+		b.palette = nil
+		for i, c := range stops {
+			pos := 0.0
+			if len(stops) > 1 {
+				pos = float64(i) / float64(len(stops)-1)
+			}
+			b.palette = append(b.palette, GradientStop{Pos: pos, Hex: c.Hex()})
+		}
+	}
+}
+
+// WithPulse enables "pulse" mode, where instead of scrolling the gradient
+// sideways the banner's lightness breathes via a sine wave.
+func WithPulse(enabled bool) Option {
+	return func(b *AnimatedBanner) { b.pulse = enabled }
+}
+
+// AnimatedBanner is a bubbletea model that owns the banner text, a phase
+// offset, and a time.Tick command, re-rendering each tick so the gradient
+// visibly shimmers/scrolls. When the terminal can't show color — NO_COLOR
+// is set, or the detected profile is termenv.Ascii — it falls back to the
+// static RenderGradientBanner instead of animating.
+type AnimatedBanner struct {
+	text      string
+	phase     float64
+	fps       int
+	direction GradientDirection
+	palette   []GradientStop
+	pulse     bool
+}
+
+// NewAnimatedBanner builds an AnimatedBanner over the package's bannerTxt,
+// using activeTheme's gradient stops unless overridden by WithPalette.
+func NewAnimatedBanner(opts ...Option) AnimatedBanner {
+	b := AnimatedBanner{
+		text:      bannerTxt,
+		fps:       12,
+		direction: GradientDiagonal,
+	}
+	for _, opt := range opts {
+		opt(&b)
+	}
+	if b.palette == nil {
+		b.palette = themeGradient(0, b.direction).Stops
+	}
+	return b
+}
+
+func (b AnimatedBanner) tickCmd() tea.Cmd {
+	interval := time.Second / time.Duration(b.fps)
+	return tea.Tick(interval, func(t time.Time) tea.Msg {
+		return bannerTickMsg(t)
+	})
+}
+
+func (b AnimatedBanner) Init() tea.Cmd {
+	if staticBannerOnly() {
+		return nil
+	}
+	return b.tickCmd()
+}
+
+func (b AnimatedBanner) Update(msg tea.Msg) (AnimatedBanner, tea.Cmd) {
+	switch msg.(type) {
+	case bannerTickMsg:
+		b.phase = math.Mod(b.phase+0.02, 1.0)
+		return b, b.tickCmd()
+	}
+	return b, nil
+}
+
+func (b AnimatedBanner) View() string {
+	if staticBannerOnly() {
+		return RenderGradientBanner()
+	}
+
+	lines := strings.Split(b.text, "\n")
+	if len(lines) > 0 && strings.TrimSpace(lines[0]) == "" {
+		lines = lines[1:]
+	}
+
+	g := GradientColor{Stops: b.palette, Steps: 40, Direction: b.direction}
+	if !b.pulse {
+		// Scroll: shift every rune's sampled blend position by the phase
+		// offset instead of the stops themselves, so the stops stay
+		// ascending-sorted for colorAt.
+		return renderScrolled(lines, g, b.phase) + "\n"
+	}
+
+	// Pulse: modulate lightness with a sine wave instead of scrolling hue.
+	lightness := 0.5 + 0.5*math.Sin(2*math.Pi*b.phase)
+	return renderPulsed(lines, g, lightness)
+}
+
+// renderScrolled paints lines with g's palette, sampling each rune's blend
+// position shifted by phase (mod 1.0) so the gradient appears to scroll
+// across the text frame by frame.
+func renderScrolled(lines []string, g GradientColor, phase float64) string {
+	var out strings.Builder
+	for y, line := range lines {
+		for x, r := range line {
+			t := math.Mod(g.position(x, y, len(lines))+phase, 1.0)
+			c := g.colorAt(t)
+			style := lipgloss.NewStyle().Foreground(lipgloss.Color(c.Hex())).Bold(true)
+			out.WriteString(style.Render(string(r)))
+		}
+		if y < len(lines)-1 {
+			out.WriteString("\n")
+		}
+	}
+	return out.String()
+}
+
+// renderPulsed paints lines with g's hue/saturation at each position but a
+// shared, sine-modulated lightness, producing the "pulse" breathing effect.
+func renderPulsed(lines []string, g GradientColor, lightness float64) string {
+	var out strings.Builder
+	for y, line := range lines {
+		for x, r := range line {
+			t := g.position(x, y, len(lines))
+			c := g.colorAt(t)
+			h, s, _ := c.Hsl()
+			pulsed := colorful.Hsl(h, s, 0.3+0.4*lightness)
+			style := lipgloss.NewStyle().Foreground(lipgloss.Color(pulsed.Hex())).Bold(true)
+			out.WriteString(style.Render(string(r)))
+		}
+		if y < len(lines)-1 {
+			out.WriteString("\n")
+		}
+	}
+	return out.String()
+}
+
+// staticBannerOnly reports whether animation should be skipped in favor of
+// the static RenderGradientBanner: NO_COLOR is set, or stdout's detected
+// color profile is ASCII-only.
+func staticBannerOnly() bool {
+	if os.Getenv("NO_COLOR") != "" {
+		return true
+	}
+	return termenv.NewOutput(os.Stdout).Profile == termenv.Ascii
+}
@@ -0,0 +1,230 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"google.golang.org/grpc"
+	"gopkg.in/yaml.v3"
+
+	pb "github.com/sjanney/prism/proto"
+)
+
+// describeSection is one labelled block of a `prism describe` output: a
+// flat list of label/value pairs, optionally followed by a bare list (e.g.
+// Detected Objects) - the same two-level shape kubectl describe uses for a
+// resource's nested fields. describeFrame/describeDataset/describeBenchmark
+// below build these once; writeDescribeTabwriter (CLI) and viewSidebar
+// (TUI) both render the same []describeSection instead of each having its
+// own ad-hoc formatting.
+type describeSection struct {
+	Title string
+	Pairs [][2]string
+	List  []string
+}
+
+// describeFrame builds the describeSections for one search result: Match
+// Type, Resolution, FileSize as top-level pairs, Detected Objects as a
+// nested list section.
+func describeFrame(frame *pb.SearchResult) []describeSection {
+	matchType := "Full Image"
+	if frame.MatchType == "object_crop" {
+		matchType = "Object Crop"
+	}
+
+	sections := []describeSection{{
+		Title: "FRAME",
+		Pairs: [][2]string{
+			{"Path", frame.Path},
+			{"Match", fmt.Sprintf("%.1f%%", frame.Confidence*100)},
+			{"Match Type", matchType},
+			{"Resolution", frame.Resolution},
+			{"File Size", frame.FileSize},
+		},
+	}}
+	if len(frame.DetectedObjects) > 0 {
+		sections = append(sections, describeSection{Title: "Detected Objects", List: frame.DetectedObjects})
+	}
+	return sections
+}
+
+// describeDataset builds the describeSections for dataset-wide metadata.
+func describeDataset(stats *pb.DatasetMetadata) []describeSection {
+	return []describeSection{{
+		Title: "DATASET",
+		Pairs: [][2]string{
+			{"Total Frames", strconv.FormatInt(int64(stats.TotalFrames), 10)},
+			{"Total Embeddings", strconv.FormatInt(int64(stats.TotalEmbeddings), 10)},
+			{"DB Path", stats.DbPath},
+			{"Last Indexed", stats.LastIndexed},
+		},
+	}}
+}
+
+// describeBenchmark builds the describeSections for a benchmark report,
+// one section per metric category - the same grouping viewBenchmark shows.
+func describeBenchmark(report *pb.BenchmarkReport) []describeSection {
+	sections := []describeSection{{
+		Title: "BENCHMARK",
+		Pairs: [][2]string{
+			{"Timestamp", report.Timestamp},
+			{"Device", report.Device},
+			{"OS", report.Os},
+			{"Prism Version", report.PrismVersion},
+		},
+	}}
+	for _, group := range []struct {
+		title   string
+		metrics []*pb.Metric
+	}{
+		{"Indexing Metrics", report.IndexingMetrics},
+		{"Search Metrics", report.SearchMetrics},
+		{"System Metrics", report.SystemMetrics},
+	} {
+		section := describeSection{Title: group.title}
+		for _, m := range group.metrics {
+			section.Pairs = append(section.Pairs, [2]string{m.Name, fmt.Sprintf("%.2f %s", m.Value, m.Unit)})
+		}
+		sections = append(sections, section)
+	}
+	return sections
+}
+
+// writeDescribeTabwriter renders sections as aligned label/value pairs via
+// text/tabwriter, in the style of `kubectl describe`.
+func writeDescribeTabwriter(w io.Writer, sections []describeSection) error {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	for i, section := range sections {
+		if i > 0 {
+			fmt.Fprintln(tw)
+		}
+		fmt.Fprintf(tw, "%s:\n", section.Title)
+		for _, pair := range section.Pairs {
+			fmt.Fprintf(tw, "  %s:\t%s\n", pair[0], pair[1])
+		}
+		for _, item := range section.List {
+			fmt.Fprintf(tw, "  - %s\n", item)
+		}
+	}
+	return tw.Flush()
+}
+
+// describeArgsFromArgs parses `prism describe <kind> [id] [-o json|yaml]`
+// out of args, returning handled=false if args doesn't start with
+// "describe" at all.
+func describeArgsFromArgs(args []string) (kind, id, outputFormat string, handled bool) {
+	if len(args) < 2 || args[1] != "describe" {
+		return "", "", "", false
+	}
+	outputFormat = "text"
+	rest := args[2:]
+	var positional []string
+	for i := 0; i < len(rest); i++ {
+		switch rest[i] {
+		case "-o", "--output":
+			if i+1 < len(rest) {
+				outputFormat = rest[i+1]
+				i++
+			}
+		default:
+			positional = append(positional, rest[i])
+		}
+	}
+	if len(positional) > 0 {
+		kind = positional[0]
+	}
+	if len(positional) > 1 {
+		id = positional[1]
+	}
+	return kind, id, outputFormat, true
+}
+
+// describeFromArgs handles `prism describe ...` before falling through to
+// the normal local TUI entry point in main(), the same early-intercept
+// shape serveFromArgs and runReportFromArgs use.
+func describeFromArgs(args []string) (handled bool) {
+	kind, id, outputFormat, handled := describeArgsFromArgs(args)
+	if !handled {
+		return false
+	}
+	if err := runDescribe(kind, id, outputFormat); err != nil {
+		fmt.Fprintln(os.Stderr, "prism:", err)
+		os.Exit(1)
+	}
+	return true
+}
+
+func runDescribe(kind, id, outputFormat string) error {
+	profile := loadedProfiles()[0]
+	opts, err := dialOptionsForProfile(profile)
+	if err != nil {
+		return fmt.Errorf("building dial options: %w", err)
+	}
+	dialCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	conn, err := grpc.DialContext(dialCtx, profile.Address, append(opts, grpc.WithBlock())...)
+	if err != nil {
+		return fmt.Errorf("dialing %s: %w", profile.Address, err)
+	}
+	defer conn.Close()
+	client := pb.NewPrismServiceClient(conn)
+
+	ctx, cancelCall := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancelCall()
+
+	switch kind {
+	case "frame":
+		if id == "" {
+			return fmt.Errorf("describe frame requires an id: prism describe frame <id>")
+		}
+		// GetFrame isn't exercised anywhere else in this tree - it's assumed
+		// analogous to the daemon's other single-entity getters
+		// (GetSystemInfo, GetBenchmarkReport) since Search only ever returns
+		// a page of SearchResults, never one by id.
+		frame, err := client.GetFrame(ctx, &pb.GetFrameRequest{Id: id})
+		if err != nil {
+			return fmt.Errorf("fetching frame %s: %w", id, err)
+		}
+		return renderDescribe(frame, describeFrame(frame), outputFormat)
+
+	case "dataset":
+		stats, err := client.GetStats(ctx, &pb.GetStatsRequest{})
+		if err != nil {
+			return fmt.Errorf("fetching dataset stats: %w", err)
+		}
+		return renderDescribe(stats, describeDataset(stats), outputFormat)
+
+	case "benchmark":
+		report, err := client.GetBenchmarkReport(ctx, &pb.GetBenchmarkReportRequest{})
+		if err != nil {
+			return fmt.Errorf("fetching benchmark report: %w", err)
+		}
+		return renderDescribe(report, describeBenchmark(report), outputFormat)
+
+	default:
+		return fmt.Errorf("unknown describe kind %q (want frame, dataset, or benchmark)", kind)
+	}
+}
+
+// renderDescribe writes raw as JSON/YAML when outputFormat asks for it
+// (for piping into jq or a script), or sections via tabwriter otherwise.
+func renderDescribe(raw any, sections []describeSection, outputFormat string) error {
+	switch outputFormat {
+	case "json":
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(raw)
+	case "yaml":
+		enc := yaml.NewEncoder(os.Stdout)
+		defer enc.Close()
+		return enc.Encode(raw)
+	default:
+		return writeDescribeTabwriter(os.Stdout, sections)
+	}
+}
@@ -0,0 +1,163 @@
+package main
+
+import (
+	"math"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	colorful "github.com/lucasb-eyer/go-colorful"
+)
+
+// GradientDirection controls how a GradientColor's blend position is
+// derived from a rune's (x, y) offset within the text it's painting.
+type GradientDirection int
+
+const (
+	GradientHorizontal GradientDirection = iota
+	GradientVertical
+	GradientDiagonal
+)
+
+// GradientStop is one color in a multi-stop palette, at a normalized
+// position in [0, 1] along the gradient.
+type GradientStop struct {
+	Pos float64
+	Hex string
+}
+
+// GradientColor generalizes the diagonal blend RenderGradientBanner used to
+// do inline: given a direction and a palette of stops, it blends in Luv
+// space (via go-colorful) between whichever two stops straddle each rune's
+// position, then emits the result as per-rune ANSI. Lipgloss styles apply a
+// single TerminalColor to an entire Render call, so GradientColor doesn't
+// implement that interface directly — instead its own Render loops
+// character-by-character, which is what RenderGradientBanner and the
+// shimmer helpers below call.
+type GradientColor struct {
+	Stops     []GradientStop
+	Steps     int
+	Direction GradientDirection
+}
+
+// NewGradientColor builds a two-stop GradientColor, the common case from
+// the original RenderGradientBanner (start hex -> end hex over Steps runes).
+func NewGradientColor(start, end string, steps int, dir GradientDirection) GradientColor {
+	return GradientColor{
+		Stops: []GradientStop{
+			{Pos: 0, Hex: start},
+			{Pos: 1, Hex: end},
+		},
+		Steps:     steps,
+		Direction: dir,
+	}
+}
+
+// colorAt returns the blended color at normalized position t in [0, 1],
+// interpolating in Luv space between whichever pair of stops straddle t.
+func (g GradientColor) colorAt(t float64) colorful.Color {
+	stops := g.Stops
+	if len(stops) == 0 {
+		return colorful.Color{}
+	}
+	if t <= stops[0].Pos {
+		c, _ := colorful.Hex(stops[0].Hex)
+		return c
+	}
+	last := stops[len(stops)-1]
+	if t >= last.Pos {
+		c, _ := colorful.Hex(last.Hex)
+		return c
+	}
+
+	for i := 0; i < len(stops)-1; i++ {
+		a, b := stops[i], stops[i+1]
+		if t >= a.Pos && t <= b.Pos {
+			ca, _ := colorful.Hex(a.Hex)
+			cb, _ := colorful.Hex(b.Hex)
+			span := b.Pos - a.Pos
+			if span <= 0 {
+				return ca
+			}
+			return ca.BlendLuv(cb, (t-a.Pos)/span)
+		}
+	}
+	c, _ := colorful.Hex(last.Hex)
+	return c
+}
+
+// position computes the normalized blend position for a rune at (x, y)
+// within a block of `height` lines, according to g.Direction.
+func (g GradientColor) position(x, y, height int) float64 {
+	steps := g.Steps
+	if steps < 1 {
+		steps = 1
+	}
+	switch g.Direction {
+	case GradientVertical:
+		if height <= 1 {
+			return 0
+		}
+		return float64(y) / float64(height-1)
+	case GradientDiagonal:
+		normX := float64(x) / float64(steps)
+		normY := 0.0
+		if height > 1 {
+			normY = float64(y) / float64(height-1)
+		}
+		return (normX + normY) / 2.0
+	default: // GradientHorizontal
+		return float64(x) / float64(steps)
+	}
+}
+
+// Render paints s with this gradient, rune by rune. It is RenderBlock under
+// another name: a multi-line s is blended per line exactly as RenderBlock
+// blends it.
+func (g GradientColor) Render(s string) string {
+	return g.RenderBlock(s)
+}
+
+// RenderBlock paints a (possibly multi-line) block of text with this
+// gradient, blending per rune according to g.Direction. This is what
+// RenderGradientBanner now delegates to.
+func (g GradientColor) RenderBlock(s string) string {
+	lines := strings.Split(s, "\n")
+	var out strings.Builder
+	for y, line := range lines {
+		x := 0
+		for _, r := range line {
+			t := math.Min(math.Max(g.position(x, y, len(lines)), 0), 1)
+			c := g.colorAt(t)
+			style := lipgloss.NewStyle().Foreground(lipgloss.Color(c.Hex())).Bold(true)
+			out.WriteString(style.Render(string(r)))
+			x++
+		}
+		if y < len(lines)-1 {
+			out.WriteString("\n")
+		}
+	}
+	return out.String()
+}
+
+// themeGradient builds the GradientColor for activeTheme's three banner
+// stops, the multi-stop palette every shimmering component shares.
+func themeGradient(steps int, dir GradientDirection) GradientColor {
+	stops := activeTheme.GradientStops
+	return GradientColor{
+		Stops: []GradientStop{
+			{Pos: 0.0, Hex: stops[0]},
+			{Pos: 0.5, Hex: stops[1]},
+			{Pos: 1.0, Hex: stops[2]},
+		},
+		Steps:     steps,
+		Direction: dir,
+	}
+}
+
+// RenderShimmerText applies the theme's gradient horizontally to a single
+// line of text, so any component — a tab border, a panel title, a
+// highlighted result — can opt into the same shimmer the banner uses
+// instead of being hardcoded to one color.
+func RenderShimmerText(s string) string {
+	return themeGradient(lipgloss.Width(s), GradientHorizontal).Render(s)
+}
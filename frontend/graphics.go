@@ -0,0 +1,126 @@
+package main
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"image"
+	"image/png"
+	"os"
+	"strings"
+
+	"github.com/qeesung/image2ascii/convert"
+)
+
+// graphicsProtocol is the terminal image transport the TUI renders
+// thumbnails through, picked once at startup by detectGraphicsProtocol.
+type graphicsProtocol int
+
+const (
+	graphicsNone graphicsProtocol = iota
+	graphicsKitty
+	graphicsITerm2
+	graphicsSixel
+	graphicsASCII
+)
+
+// activeGraphics is the protocol selected for this run, populated by
+// detectGraphicsProtocol during startup. Kept as a package var rather than
+// threaded through model, matching how activeTheme is resolved once at
+// startup and read everywhere after.
+var activeGraphics = graphicsASCII
+
+// detectGraphicsProtocol picks the richest image transport the current
+// terminal is likely to support. forceASCII is set by the --no-graphics
+// flag, for CI/plain terminals where escape-sequence probing would just
+// dump garbage into the log.
+//
+// This uses environment hints (KITTY_WINDOW_ID, TERM_PROGRAM, a TERM that
+// names sixel, WEZTERM_PANE) rather than writing \x1b_G / \x1b]1337 /
+// DA1 queries and blocking on a reply, since doing that safely means
+// putting stdin in raw mode before the bubbletea program has started -
+// these are the same signals chafa and viu key off for their fast path.
+func detectGraphicsProtocol(forceASCII bool) graphicsProtocol {
+	if forceASCII || os.Getenv("NO_COLOR") != "" {
+		return graphicsASCII
+	}
+
+	if os.Getenv("KITTY_WINDOW_ID") != "" || strings.Contains(os.Getenv("TERM"), "kitty") {
+		return graphicsKitty
+	}
+	if os.Getenv("TERM_PROGRAM") == "iTerm.app" || os.Getenv("TERM_PROGRAM") == "WezTerm" {
+		return graphicsITerm2
+	}
+	if term := os.Getenv("TERM"); strings.Contains(term, "sixel") || os.Getenv("WEZTERM_PANE") != "" {
+		return graphicsSixel
+	}
+	return graphicsASCII
+}
+
+// renderThumbnail encodes img for display through proto. Only the ASCII
+// fallback is scaled to maxW columns / maxH rows; the Kitty and iTerm2
+// escapes carry img at the pixel size it was decoded at.
+func renderThumbnail(img image.Image, proto graphicsProtocol, maxW, maxH int) string {
+	switch proto {
+	case graphicsKitty:
+		return renderKitty(img)
+	case graphicsITerm2:
+		return renderITerm2(img)
+	case graphicsSixel:
+		// No local Sixel encoder is vendored; fall through to the ASCII
+		// renderer rather than emit raw pixels the terminal can't decode.
+		return renderASCII(img, maxW, maxH)
+	default:
+		return renderASCII(img, maxW, maxH)
+	}
+}
+
+// renderKitty wraps img's PNG bytes in the Kitty graphics protocol's
+// \x1b_G...\x1b\\ APC, transmitting and displaying it in one action (a=T).
+func renderKitty(img image.Image) string {
+	png, err := encodePNG(img)
+	if err != nil {
+		return ""
+	}
+	payload := base64.StdEncoding.EncodeToString(png)
+	return fmt.Sprintf("\x1b_Ga=T,f=100,t=d;%s\x1b\\", payload)
+}
+
+// renderITerm2 wraps img's PNG bytes in iTerm2's inline image OSC.
+func renderITerm2(img image.Image) string {
+	png, err := encodePNG(img)
+	if err != nil {
+		return ""
+	}
+	payload := base64.StdEncoding.EncodeToString(png)
+	return fmt.Sprintf("\x1b]1337;File=inline=1;size=%d:%s\a", len(png), payload)
+}
+
+// renderASCII is the fallback for terminals with none of the graphics
+// protocols above: half-block/ASCII art via image2ascii, sized to fit the
+// detail panel.
+func renderASCII(img image.Image, maxW, maxH int) string {
+	converter := convert.NewImageConverter()
+	opts := convert.DefaultOptions
+	opts.FixedWidth = maxW
+	opts.FixedHeight = maxH
+	return converter.Image2ASCIIString(img, &opts)
+}
+
+// hasFlag reports whether name appears verbatim among args.
+func hasFlag(args []string, name string) bool {
+	for _, a := range args {
+		if a == name {
+			return true
+		}
+	}
+	return false
+}
+
+func encodePNG(img image.Image) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -1,21 +1,38 @@
 package main
 
 import (
+	"bytes"
 	"context"
+	"errors"
 	"fmt"
+	"image"
+	_ "image/jpeg"
 	"io"
 	"log"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"sort"
 	"strings"
 	"time"
 
 	"github.com/charmbracelet/bubbles/progress"
 	"github.com/charmbracelet/bubbles/spinner"
 	"github.com/charmbracelet/bubbles/textinput"
+	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"golang.org/x/time/rate"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/connectivity"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
 
+	"github.com/sjanney/prism/locks"
+	"github.com/sjanney/prism/notify"
+	"github.com/sjanney/prism/plugins"
 	pb "github.com/sjanney/prism/proto"
 )
 
@@ -26,11 +43,16 @@ const (
 	stateHome
 	stateSearch
 	stateIndex
+	stateLogs // Live daemon log stream
 	stateConnectDB
 	stateSettings
 	statePro
 	stateCloudConfig // Cloud: Configure AWS/Azure
-	stateBenchmark   // Developer Mode: Benchmarks & Diagnostics
+	stateBenchmark    // Developer Mode: Benchmarks & Diagnostics
+	stateProfiles     // Ctrl+P connection picker, overlaid on whatever state it was opened from
+	statePlugin       // A third-party plugins.Panel is showing, see activePlugin
+	stateNotifyConfig // Settings: configure event-bus notification sinks
+	stateCommunity    // Settings: Community Benchmarks panel, see telemetry.go
 )
 
 // Notification types for the sidebar
@@ -61,6 +83,11 @@ type model struct {
 	width  int
 	height int
 
+	// styles is the renderer-bound style set this model draws with. The
+	// local TUI uses defaultStyles(); an SSH session from `prism serve`
+	// overrides it with one bound to that session's own renderer.
+	styles Styles
+
 	// Home / Dashboard
 	dashboardOptions []string
 	dashboardCursor  int
@@ -73,30 +100,73 @@ type model struct {
 	connecting bool
 
 	// Search
-	searchInput textinput.Model
-	results     []*pb.SearchResult
-	cursor      int
-	page        int
-	searching   bool
+	searchInput     textinput.Model
+	searchQuery     string
+	results         []*pb.SearchResult
+	cursor          int
+	searching       bool
+	resultsViewport viewport.Model
+	searchOffset    int32
+	searchHasMore   bool
+	loadingMore     bool
+
+	// Thumbnail preview of the selected result, rendered through
+	// activeGraphics and cached in thumbCache so scrolling back over an
+	// already-visited result doesn't re-fetch it.
+	thumbCache    *thumbnailCache
+	thumbRendered string
+	thumbLoading  bool
 
 	// Global Spinner
 	spinner spinner.Model
 
-	// Loading Screen
-	loadingPercent float64
-	loadingLog     string
+	// Loading Screen / connection lifecycle. connState and healthy are
+	// kept up to date by watchConnStateCmd and the health-watch stream for
+	// as long as the process runs, not just during the initial loading
+	// screen, so the footer/sidebar can reflect a mid-session disconnect.
+	connState   connectivity.State
+	connAttempt int
+	connBackoff time.Duration
+	connErr     error
+	healthy     bool
+	banner      AnimatedBanner
 
 	// Index
 	pathInput    textinput.Model
 	progress     progress.Model
 	indexing     bool
+	indexPaused  bool
 	indexStatus  string
 	indexCurrent int64
 	indexTotal   int64
+	indexJobID   string
+	indexCancel  context.CancelFunc
+
+	// indexLockRelease is the FileLock's release func, held from a
+	// successful acquireIndexLockCmd until the job ends so it can be
+	// released exactly once. indexLockHolder is set instead whenever
+	// acquisition fails, naming whoever currently holds the lock so the
+	// devMode "force-steal" key binding has something to show.
+	indexLockRelease context.CancelFunc
+	indexLockHolder  string
+
+	// indexRate is an EWMA of files/sec, recomputed from the wall-clock
+	// delta between successive indexStreamMsg updates; indexLastCurrent
+	// and indexLastSample are the previous sample it diffs against.
+	indexRate        float64
+	indexLastCurrent int64
+	indexLastSample  time.Time
+
 	// Settings / System Info
 	sysInfo    *pb.GetSystemInfoResponse
 	loadingSys bool
 
+	// Logs (stateLogs) — a ring buffer fed by the StreamLogs RPC
+	logs            []*pb.LogEntry
+	logStreamActive bool
+	logLimiter      *rate.Limiter
+	logFilterInput  textinput.Model
+
 	// Pro
 	licenseInput textinput.Model
 	proStatus    string
@@ -109,16 +179,49 @@ type model struct {
 	benchmarkProgress string
 	devMode           bool
 
+	// Benchmark export (viewBenchmark's [e] key opens a picker of
+	// reportFormats, see reporter.go)
+	benchmarkExporting bool
+	exportCursor       int
+	exportStatus       string
+
+	// Telemetry (opt-in benchmark submission, see telemetry.go) and the
+	// Community Benchmarks panel (stateCommunity) it feeds.
+	telemetry           TelemetryConfig
+	submittingTelemetry bool
+	telemetryStatus     string
+	communitySummary    *CommunitySummary
+	communityErr        error
+	loadingCommunity    bool
+
+	// Live Diagnostics (MetricsStream sparklines, next to Benchmarks)
+	metricsWatching      bool
+	metricsScrapeEnabled bool
+	metricsSeries        map[string][]float64
+	metricsUnits         map[string]string
+	metricsErr           error
+
 	// Cloud Config
 
-	cloudProvider   int // 0=AWS, 1=Azure
+	cloudProvider int // 0=AWS, 1=Azure
+
+	// cloudAuthMode picks which of cloudInputs() is shown/focusable for the
+	// current provider: AWS is 0=access keys, 1=AssumeRole, 2=instance
+	// profile (IMDSv2, no fields at all); Azure is 0=connection string,
+	// 1=SAS token, 2=managed identity (also no fields).
+	cloudAuthMode   int
 	awsAccessKey    textinput.Model
 	awsSecretKey    textinput.Model
 	awsRegion       textinput.Model
+	awsRoleArn      textinput.Model
+	awsExternalID   textinput.Model
 	azureConnStr    textinput.Model
+	azureSasToken   textinput.Model
 	cloudStatus     string
+	cloudLatencyMs  int64
+	cloudRegion     string
 	savingCloud     bool
-	cloudFocusIndex int // 0-2 for AWS, 0-0 for Azure
+	cloudFocusIndex int // index into cloudInputs() for the active provider/authMode
 
 	// Notifications
 	notifications []Notification
@@ -126,14 +229,54 @@ type model struct {
 	// Index stats (enhanced)
 	indexSkipped int64
 	indexETA     int32
+
+	// indexResumed/indexDeduped come from a resumed run's IndexProgress:
+	// how many manifest entries were skipped outright (resumed) vs. how
+	// many files hashed to a sha256 already in the manifest under a
+	// different path (deduped).
+	indexResumed int64
+	indexDeduped int64
+
+	// indexEvents is a capped ring of the structured IndexEvents the
+	// daemon emits alongside each IndexProgress tick, newest last - the
+	// same bounded-buffer shape logs (logRingSize) uses. indexStages
+	// tracks each pipeline stage's started/completed state keyed by
+	// name, and indexEmbedLatencies is a capped ring of embed_ms
+	// samples the p50/p95 readout in viewIndex is computed from.
+	indexEvents         []indexEvent
+	indexStages         map[string]*indexStageState
+	indexEmbedLatencies []float64
+
+	// Profiles (Ctrl+P connection picker)
+	profiles         []Profile
+	activeProfile    int
+	profileCursor    int
+	preProfilesState state
+
+	// Plugins (third-party dashboard panels, see plugins.Registry)
+	pluginRegistry *plugins.Registry
+	activePlugin   plugins.Panel
+	prePluginState state
+
+	// Event bus (structured notifications piped to external sinks)
+	eventBus            *notify.Bus
+	notifyStdoutEnabled bool
+	notifyWebhookURL    textinput.Model
+	notifyWebhookToken  textinput.Model
+	notifyUnixSocket    textinput.Model
+	notifyFocusIndex    int // 0=webhook URL, 1=webhook token, 2=unix socket path
+	notifyStatus        string
+	savingNotify        bool
 }
 
 func initialModel() model {
+	styles := defaultStyles()
+
 	si := textinput.New()
 	si.Placeholder = "Search query (e.g. 'red car')"
 	si.CharLimit = 156
 	si.Width = 60
-	si.TextStyle = inputPromptStyle
+	si.TextStyle = styles.InputPrompt
 
 	pi := textinput.New()
 	pi.Placeholder = "/absolute/path/to/dataset"
@@ -152,11 +295,18 @@ func initialModel() model {
 	s.Spinner = spinner.Dot
 	s.Style = lipgloss.NewStyle().Foreground(lipgloss.Color("205"))
 
+	rv := viewport.New(0, 0)
+
+	lf := textinput.New()
+	lf.Placeholder = "filter (level + substring, e.g. \"error indexer\")"
+	lf.CharLimit = 128
+	lf.Width = 50
+
 	li := textinput.New()
 	li.Placeholder = "PRISM-PRO-XXXX-XXXX"
 	li.CharLimit = 36
 	li.Width = 40
-	li.TextStyle = inputPromptStyle
+	li.TextStyle = styles.InputPrompt
 
 	// Cloud Inputs
 	// AWS
@@ -176,6 +326,16 @@ func initialModel() model {
 	awsReg.CharLimit = 32
 	awsReg.Width = 20
 
+	awsRole := textinput.New()
+	awsRole.Placeholder = "arn:aws:iam::123456789012:role/prism-ingest"
+	awsRole.CharLimit = 256
+	awsRole.Width = 60
+
+	awsExtID := textinput.New()
+	awsExtID.Placeholder = "External ID (optional)"
+	awsExtID.CharLimit = 128
+	awsExtID.Width = 40
+
 	// Azure
 	azConn := textinput.New()
 	azConn.Placeholder = "Azure Storage Connection String"
@@ -183,66 +343,242 @@ func initialModel() model {
 	azConn.CharLimit = 512
 	azConn.Width = 60
 
+	azSas := textinput.New()
+	azSas.Placeholder = "SAS Token (?sv=...&sig=...)"
+	azSas.EchoMode = textinput.EchoPassword
+	azSas.CharLimit = 512
+	azSas.Width = 60
+
+	webhookURL := textinput.New()
+	webhookURL.Placeholder = "https://hooks.example.com/prism"
+	webhookURL.CharLimit = 256
+	webhookURL.Width = 50
+
+	webhookToken := textinput.New()
+	webhookToken.Placeholder = "Webhook auth token (sent as Bearer)"
+	webhookToken.EchoMode = textinput.EchoPassword
+	webhookToken.CharLimit = 256
+	webhookToken.Width = 50
+
+	unixSocket := textinput.New()
+	unixSocket.Placeholder = "/tmp/prism-events.sock"
+	unixSocket.CharLimit = 256
+	unixSocket.Width = 50
+
+	registry := loadedPlugins()
+	dashboardOptions := []string{"Search", "Ingest Data", "Connect Database", "Settings"}
+	for _, p := range registry.Panels() {
+		dashboardOptions = append(dashboardOptions, p.Title())
+	}
+
 	return model{
 		state:            stateLoading,
-		dashboardOptions: []string{"Search", "Ingest Data", "Connect Database", "Settings"},
+		styles:           styles,
+		dashboardOptions: dashboardOptions,
 		dashboardCursor:  0,
+		pluginRegistry:   registry,
 		searchInput:      si,
 		pathInput:        pi,
 		dbInput:          di,
 
-		progress:       prog,
-		spinner:        s,
-		loadingStats:   true,
-		loadingPercent: 0,
-		loadingLog:     "Initializing...",
+		progress:        prog,
+		spinner:         s,
+		loadingStats:    true,
+		connState:       connectivity.Idle,
+		connBackoff:     connBackoffMin,
+		banner:          NewAnimatedBanner(),
+		resultsViewport: rv,
+		thumbCache:      newThumbnailCache(),
+
+		logLimiter:     rate.NewLimiter(rate.Every(100*time.Millisecond), 1),
+		logFilterInput: lf,
 
 		licenseInput: li,
 
 		awsAccessKey:  awsAk,
 		awsSecretKey:  awsSk,
 		awsRegion:     awsReg,
+		awsRoleArn:    awsRole,
+		awsExternalID: awsExtID,
 		azureConnStr:  azConn,
+		azureSasToken: azSas,
 		cloudProvider: 0, // Default AWS
 
 		notifications: []Notification{
 			{Type: NotifyInfo, Message: "Prism initialized", Timestamp: time.Now()},
 		},
+
+		profiles: loadedProfiles(),
+
+		eventBus:           notify.NewBus(),
+		notifyWebhookURL:   webhookURL,
+		notifyWebhookToken: webhookToken,
+		notifyUnixSocket:   unixSocket,
+
+		metricsScrapeEnabled: true,
+		metricsSeries:        make(map[string][]float64),
+		metricsUnits:         make(map[string]string),
+
+		indexStages: make(map[string]*indexStageState),
+
+		telemetry: loadedTelemetryConfig(),
 	}
 }
 
-// Tick for loading simulation
-type tickMsg time.Time
+// loadedProfiles reads ~/.config/prism/profiles.toml, falling back to the
+// built-in localDevProfile if it's missing or fails to parse.
+func loadedProfiles() []Profile {
+	profiles, err := loadProfiles(defaultProfilesPath())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "prism: %v; using default profile\n", err)
+	}
+	if len(profiles) == 0 {
+		return []Profile{localDevProfile()}
+	}
+	return profiles
+}
 
-func tickCmd() tea.Cmd {
-	return tea.Tick(time.Millisecond*100, func(t time.Time) tea.Msg {
-		return tickMsg(t)
-	})
+// loadedPlugins opens ~/.config/prism/plugins, falling back to an empty
+// Registry if the directory itself can't be read. A plugin that individually
+// fails to load doesn't take the others down with it - registry still holds
+// every plugin that did load, and the failures are just logged.
+func loadedPlugins() *plugins.Registry {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return plugins.NewRegistry()
+	}
+	registry, err := plugins.LoadDir(filepath.Join(dir, "prism", "plugins"))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "prism: %v; continuing with any plugins that did load\n", err)
+	}
+	if registry == nil {
+		return plugins.NewRegistry()
+	}
+	return registry
 }
 
 func (m model) Init() tea.Cmd {
 	return tea.Batch(
 		textinput.Blink,
 		m.spinner.Tick,
-		tickCmd(), // Start simulated loading
-		connectToBackendWithRetry,
+		m.banner.Init(),
+		dialBackendCmd(0, m.profiles[m.activeProfile]),
 	)
 }
 
 // -- Messages --
-type connMsg *grpc.ClientConn
+
+// connDialedMsg carries the result of one dialBackendCmd attempt: either a
+// live (though not necessarily Ready yet) *grpc.ClientConn, or the error
+// from that attempt so Update can schedule a backed-off retry.
+type connDialedMsg struct {
+	conn    *grpc.ClientConn
+	err     error
+	attempt int
+	profile Profile
+}
+
+// connStateMsg reports conn's connectivity.State after it changed, per
+// watchConnStateCmd's WaitForStateChange poll.
+type connStateMsg struct {
+	conn  *grpc.ClientConn
+	state connectivity.State
+}
+
+// healthWatchMsg carries one response off the grpc_health_v1 Health/Watch
+// stream, following the same stream-plus-Recv-error shape as
+// indexStreamMsg/benchmarkProgressMsg/logStreamMsg.
+type healthWatchMsg struct {
+	stream grpc_health_v1.Health_WatchClient
+	resp   *grpc_health_v1.HealthCheckResponse
+	err    error
+}
+
 type dbConnectedMsg struct {
 	success bool
 	message string
 }
-type searchResultsMsg []*pb.SearchResult
+
+// searchResultsMsg carries one page of Search results back from searchCmd.
+// append is true for pages fetched by loadMoreResultsCmd (offset > 0), so
+// Update knows to extend m.results instead of replacing it.
+type searchResultsMsg struct {
+	results []*pb.SearchResult
+	offset  int32
+	hasMore bool
+	append  bool
+}
+// thumbnailMsg carries a rendered thumbnail (or fetch/decode error) back
+// for the search result keyed by key (see thumbnailCacheKey).
+type thumbnailMsg struct {
+	key      string
+	rendered string
+	err      error
+}
 type statsMsg *pb.DatasetMetadata
+// indexStreamMsg carries one update off the Index RPC stream. cancel is
+// only set on the first message (from startIndexCmd) so Update can stash
+// the stream's CancelFunc once, for the "x" cancel key to call directly
+// without waiting on a ControlIndex round trip.
 type indexStreamMsg struct {
 	stream pb.PrismService_IndexClient
 	data   *pb.IndexProgress
 	err    error
+	cancel context.CancelFunc
 }
 type indexDoneMsg struct{}
+
+// indexStageNames is the scan → decode → embed → detect → persist pipeline
+// a run's IndexEvents describe, in the fixed order the stacked progress
+// bar renders them regardless of arrival order.
+var indexStageNames = []string{"scan", "decode", "embed", "detect", "persist"}
+
+// indexEventRingSize caps how many structured IndexEvents the model keeps
+// for the live log and per-stage throughput window, the same bounded-ring
+// approach logRingSize uses for StreamLogs entries.
+const indexEventRingSize = 200
+
+// indexThroughputWindow is how far back file_processed events are
+// considered when computing a stage's sliding files/s and MB/s.
+const indexThroughputWindow = 5 * time.Second
+
+// indexEvent is the TUI's local view of one pb.IndexEvent, timestamped on
+// arrival since the daemon doesn't stamp one itself. pb.IndexProgress is
+// assumed to have gained an Events []*pb.IndexEvent field alongside its
+// existing Current/Total/StatusMessage fields, with IndexEvent itself
+// flat (kind/stage/path/bytes/embed_ms/reason/attempt/duration_ms) in the
+// same style as pb.Metric rather than a oneof - this isn't exercised
+// anywhere else in this tree, so it's a best-effort guess at the shape
+// Ginkgo-style typed events would take on this daemon's wire format.
+type indexEvent struct {
+	at       time.Time
+	kind     string // "stage_started", "file_processed", "file_skipped", "file_retried", "stage_completed"
+	stage    string
+	path     string
+	bytes    int64
+	embedMs  float64
+	reason   string
+	attempt  int32
+	duration time.Duration
+}
+
+// indexStageState tracks one pipeline stage's lifecycle for the stacked
+// progress bar - just started/completed, since per-stage throughput is
+// computed on demand from the indexEvents ring rather than accumulated
+// here.
+type indexStageState struct {
+	started   bool
+	completed bool
+	duration  time.Duration
+}
+
+// indexControlMsg carries the result of a ControlIndex RPC (pause/resume/
+// cancel) back to Update.
+type indexControlMsg struct {
+	action  string
+	success bool
+	message string
+}
 type openResultMsg string
 type licenseActivatedMsg struct {
 	success bool
@@ -254,7 +590,6 @@ type folderPickedMsg struct {
 	message string
 }
 type errMsg error
-type retryConnectMsg struct{}
 
 // Benchmark messages
 type benchmarkProgressMsg struct {
@@ -264,6 +599,31 @@ type benchmarkProgressMsg struct {
 }
 type benchmarkReportMsg *pb.BenchmarkReport
 
+// metricsStreamSize caps how many samples each sparkline series keeps, wide
+// enough to fill the Diagnostics panel without growing unbounded across a
+// long-running watch.
+const metricsStreamSize = 40
+
+// metricsStreamMsg carries one sample batch off the MetricsStream RPC,
+// following the same stream-plus-Recv-error shape as benchmarkProgressMsg.
+type metricsStreamMsg struct {
+	stream pb.PrismService_MetricsStreamClient
+	data   *pb.MetricsStreamResponse
+	err    error
+}
+
+// logRingSize caps how many StreamLogs entries the model keeps, so a
+// chatty daemon can't grow m.logs without bound.
+const logRingSize = 500
+
+// logStreamMsg carries one entry off the StreamLogs RPC, following the same
+// stream-plus-Recv-error shape as indexStreamMsg/benchmarkProgressMsg.
+type logStreamMsg struct {
+	stream pb.PrismService_StreamLogsClient
+	entry  *pb.LogEntry
+	err    error
+}
+
 // -- Commands --
 // ... (existing commands same) ...
 
@@ -279,23 +639,91 @@ func pickFolderCmd(client pb.PrismServiceClient) tea.Cmd {
 	}
 }
 
-func connectToBackendWithRetry() tea.Msg {
-	ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
-	defer cancel()
+// connBackoffMin/connBackoffMax bound dialBackendCmd's retry delay: it
+// starts at connBackoffMin and doubles up to connBackoffMax, with up to 20%
+// jitter added on top of each doubled delay (see nextConnBackoff), mirroring how ceremonyclient's db_console backs off reconnect attempts
+// rather than hammering a backend that's still starting up.
+const (
+	connBackoffMin = 500 * time.Millisecond
+	connBackoffMax = 8 * time.Second
+)
 
-	conn, err := grpc.DialContext(ctx, "localhost:50051", grpc.WithTransportCredentials(insecure.NewCredentials()), grpc.WithBlock())
-	if err != nil {
-		return retryConnectMsg{}
+// dialBackendCmd makes one dial attempt against profile. On success the
+// returned conn isn't necessarily Ready yet — watchConnStateCmd takes over
+// from there to track its connectivity.State for the life of the process.
+// On failure, Update schedules another dialBackendCmd after an
+// exponentially backed-off delay.
+func dialBackendCmd(attempt int, profile Profile) tea.Cmd {
+	return func() tea.Msg {
+		opts, err := dialOptionsForProfile(profile)
+		if err != nil {
+			return connDialedMsg{err: err, attempt: attempt, profile: profile}
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+		defer cancel()
+
+		conn, err := grpc.DialContext(ctx, profile.Address, append(opts, grpc.WithBlock())...)
+		return connDialedMsg{conn: conn, err: err, attempt: attempt, profile: profile}
+	}
+}
+
+// nextConnBackoff doubles d, capped at connBackoffMax, then adds a random
+// 0-20% of that on top (so the result can exceed connBackoffMax by up to a
+// fifth) so a crashed-and-restarting backend doesn't get hammered by every
+// client retrying in lockstep.
+func nextConnBackoff(d time.Duration) time.Duration {
+	d *= 2
+	if d > connBackoffMax {
+		d = connBackoffMax
 	}
-	return connMsg(conn)
+	jitter := time.Duration(rand.Int63n(int64(d) / 5))
+	return d + jitter
 }
 
-func waitForRetry() tea.Cmd {
-	return tea.Tick(1*time.Second, func(_ time.Time) tea.Msg {
-		return connectToBackendWithRetry()
+// backoffRetryCmd waits d, then makes another dial attempt against profile.
+func backoffRetryCmd(d time.Duration, attempt int, profile Profile) tea.Cmd {
+	return tea.Tick(d, func(time.Time) tea.Msg {
+		return dialBackendCmd(attempt, profile)()
 	})
 }
 
+// watchConnStateCmd blocks until conn's connectivity.State changes from
+// last (or a short timeout elapses, in which case it reports the unchanged
+// state so the poll loop keeps turning over). Update re-issues this after
+// every connStateMsg, so it effectively watches conn for as long as the
+// program runs.
+func watchConnStateCmd(conn *grpc.ClientConn, last connectivity.State) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		conn.WaitForStateChange(ctx, last)
+		return connStateMsg{conn: conn, state: conn.GetState()}
+	}
+}
+
+// startHealthWatchCmd opens the standard grpc_health_v1 Health/Watch stream
+// once conn reaches Ready, so m.healthy reflects the server's own view of
+// its health rather than just "the TCP connection is up".
+func startHealthWatchCmd(conn *grpc.ClientConn) tea.Cmd {
+	return func() tea.Msg {
+		ctx := context.Background()
+		stream, err := grpc_health_v1.NewHealthClient(conn).Watch(ctx, &grpc_health_v1.HealthCheckRequest{})
+		if err != nil {
+			return healthWatchMsg{err: err}
+		}
+		resp, err := stream.Recv()
+		return healthWatchMsg{stream: stream, resp: resp, err: err}
+	}
+}
+
+func nextHealthWatchCmd(stream grpc_health_v1.Health_WatchClient) tea.Cmd {
+	return func() tea.Msg {
+		resp, err := stream.Recv()
+		return healthWatchMsg{stream: stream, resp: resp, err: err}
+	}
+}
+
 func getStatsCmd(client pb.PrismServiceClient) tea.Cmd {
 	return func() tea.Msg {
 		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
@@ -344,27 +772,190 @@ func activateLicenseCmd(client pb.PrismServiceClient, key string) tea.Cmd {
 	}
 }
 
-func searchCmd(client pb.PrismServiceClient, query string) tea.Cmd {
+// searchPageLimit is how many results a single Search RPC asks for at a
+// time; loadMoreResultsCmd requests subsequent pages of this same size as
+// the user scrolls past the currently loaded window.
+const searchPageLimit = 30
+
+func searchCmd(client pb.PrismServiceClient, query string, offset int32) tea.Cmd {
 	return func() tea.Msg {
 		ctx, cancel := context.WithTimeout(context.Background(), 180*time.Second) // Ultra long timeout for slow model download/load
 		defer cancel()
-		resp, err := client.Search(ctx, &pb.SearchRequest{QueryText: query})
+		resp, err := client.Search(ctx, &pb.SearchRequest{
+			QueryText: query,
+			Offset:    offset,
+			Limit:     searchPageLimit,
+		})
 		if err != nil {
 			return errMsg(err)
 		}
-		return searchResultsMsg(resp.Results)
+		return searchResultsMsg{
+			results: resp.Results,
+			offset:  offset,
+			hasMore: resp.HasMore,
+			append:  offset > 0,
+		}
+	}
+}
+
+// loadMoreResultsCmd fetches the next page of search results starting at
+// offset and appends it to what's already loaded, for when the user scrolls
+// the results viewport past the current window.
+func loadMoreResultsCmd(client pb.PrismServiceClient, query string, offset int32) tea.Cmd {
+	return searchCmd(client, query, offset)
+}
+
+// thumbnailMaxW/thumbnailMaxH bound the preview panel's size, sized to fit
+// the sidebar's 30-column width alongside the SELECTED FRAME metadata.
+const (
+	thumbnailMaxW = 26
+	thumbnailMaxH = 12
+)
+
+// thumbnailCacheKey derives a cache key that changes if the file at path is
+// overwritten, by folding in its mtime - so a re-indexed file doesn't keep
+// serving a stale cached thumbnail.
+func thumbnailCacheKey(path string) string {
+	info, err := os.Stat(path)
+	if err != nil {
+		return path
+	}
+	return fmt.Sprintf("%s@%d", path, info.ModTime().UnixNano())
+}
+
+// getThumbnailCmd fetches and decodes a pre-rendered thumbnail for path via
+// GetThumbnail, then renders it through activeGraphics.
+func getThumbnailCmd(client pb.PrismServiceClient, path, key string) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		resp, err := client.GetThumbnail(ctx, &pb.GetThumbnailRequest{
+			Path:      path,
+			MaxWidth:  thumbnailMaxW,
+			MaxHeight: thumbnailMaxH,
+		})
+		if err != nil {
+			return thumbnailMsg{key: key, err: err}
+		}
+		img, _, err := image.Decode(bytes.NewReader(resp.Data))
+		if err != nil {
+			return thumbnailMsg{key: key, err: err}
+		}
+		return thumbnailMsg{key: key, rendered: renderThumbnail(img, activeGraphics, thumbnailMaxW, thumbnailMaxH)}
+	}
+}
+
+// refreshThumbnail updates m.thumbRendered for the currently selected
+// search result: serving a cached render immediately on a hit, or kicking
+// off getThumbnailCmd (appended to cmds) on a miss.
+func (m *model) refreshThumbnail(cmds *[]tea.Cmd) {
+	if m.state != stateSearch || m.cursor >= len(m.results) {
+		return
+	}
+	path := m.results[m.cursor].Path
+	key := thumbnailCacheKey(path)
+	if rendered, ok := m.thumbCache.get(key); ok {
+		m.thumbRendered = rendered
+		m.thumbLoading = false
+		return
+	}
+	m.thumbRendered = ""
+	m.thumbLoading = true
+	*cmds = append(*cmds, getThumbnailCmd(m.client, path, key))
+}
+
+// indexManifestName is the daemon's content-addressed dedup manifest,
+// <dataset>/.prism-manifest.jsonl, one JSON line per {path, size, mtime,
+// sha256, embedding_id, status}. The TUI never reads or writes it directly -
+// writing it and skipping already-hashed entries is the daemon's job - but
+// it checks for the file's presence next to the dataset to decide whether
+// this run should ask to resume.
+const indexManifestName = ".prism-manifest.jsonl"
+
+// indexLockMsg reports the outcome of acquireIndexLockCmd: either release
+// (call it once the job ends to unlock) or err (ErrLocked if someone else
+// already holds it). resume is set when path already has an
+// indexManifestName from a prior run, worth resuming rather than
+// re-indexing from scratch.
+type indexLockMsg struct {
+	path    string
+	release context.CancelFunc
+	resume  bool
+	err     error
+}
+
+// acquireIndexLockCmd takes a FileLock over <path>/.prism.lock before the
+// Index stream opens, so two TUIs (or daemons) pointed at the same dataset
+// can't race each other into indexing it concurrently. The daemon's own
+// Index/RunBenchmark RPC handlers would need the equivalent lock server-side
+// to cover daemons on different hosts sharing one dataset - that half lives
+// outside this repo, same as the MetricsStream daemon instrumentation. steal
+// takes the lock via Lock.Steal, for the devMode "force-steal" key binding;
+// on a FileLock that waits for the current holder to release it rather than
+// breaking its flock.
+func acquireIndexLockCmd(path string, steal bool) tea.Cmd {
+	return func() tea.Msg {
+		lock := locks.NewFileLock(filepath.Join(path, ".prism.lock"))
+		acquire := lock.Acquire
+		if steal {
+			acquire = lock.Steal
+		}
+		release, err := acquire(context.Background())
+		if err != nil {
+			return indexLockMsg{path: path, err: err}
+		}
+		_, statErr := os.Stat(filepath.Join(path, indexManifestName))
+		return indexLockMsg{path: path, release: release, resume: statErr == nil}
 	}
 }
 
-func startIndexCmd(client pb.PrismServiceClient, path string) tea.Cmd {
+// startIndexCmd opens the Index stream over a cancelable context, so the
+// "x" key can tear it down immediately via the returned CancelFunc rather
+// than waiting for a ControlIndex round trip to the daemon. resume asks the
+// daemon to skip any file whose sha256 already matches an entry in
+// indexManifestName instead of re-indexing the whole dataset.
+func startIndexCmd(client pb.PrismServiceClient, path string, resume bool) tea.Cmd {
 	return func() tea.Msg {
-		ctx := context.Background()
-		stream, err := client.Index(ctx, &pb.IndexRequest{Path: path})
+		ctx, cancel := context.WithCancel(context.Background())
+		stream, err := client.Index(ctx, &pb.IndexRequest{Path: path, Resume: resume})
 		if err != nil {
-			return errMsg(err)
+			cancel()
+			return indexStreamMsg{err: err}
 		}
 		msg, err := stream.Recv()
-		return indexStreamMsg{stream: stream, data: msg, err: err}
+		return indexStreamMsg{stream: stream, data: msg, err: err, cancel: cancel}
+	}
+}
+
+// attachIndexCmd re-attaches to an already-running index job, for when the
+// TUI reconnects to the daemon mid-job (e.g. after the connection dropped
+// and watchConnStateCmd brought it back to Ready) and finds m.indexJobID
+// still set.
+func attachIndexCmd(client pb.PrismServiceClient, jobID string) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithCancel(context.Background())
+		stream, err := client.Index(ctx, &pb.IndexRequest{JobId: jobID})
+		if err != nil {
+			cancel()
+			return indexStreamMsg{err: err}
+		}
+		msg, err := stream.Recv()
+		return indexStreamMsg{stream: stream, data: msg, err: err, cancel: cancel}
+	}
+}
+
+// controlIndexCmd sends a pause/resume/cancel action for the running index
+// job to the daemon via ControlIndex, so the job's state is tracked
+// server-side even if the TUI disconnects before it's seen to complete.
+func controlIndexCmd(client pb.PrismServiceClient, jobID, action string) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		resp, err := client.ControlIndex(ctx, &pb.ControlIndexRequest{JobId: jobID, Action: action})
+		if err != nil {
+			return errMsg(err)
+		}
+		return indexControlMsg{action: action, success: resp.Success, message: resp.Message}
 	}
 }
 
@@ -410,6 +1001,39 @@ func nextBenchmarkCmd(stream pb.PrismService_RunBenchmarkClient) tea.Cmd {
 	}
 }
 
+// exportedReportMsg carries the result of one exportBenchmarkReportCmd
+// write: the format/path it was asked to produce, plus a non-nil err on
+// failure.
+type exportedReportMsg struct {
+	format string
+	path   string
+	err    error
+}
+
+// exportBenchmarkReportCmd drives reporterForFormat(format) over report and
+// writes the result to a default filename in the current directory,
+// the same "prism-benchmark.<ext>" name regardless of run so re-exporting
+// overwrites rather than accumulating files.
+func exportBenchmarkReportCmd(report *pb.BenchmarkReport, format string) tea.Cmd {
+	return func() tea.Msg {
+		path := "prism-benchmark." + reportFormatExt(format)
+		f, err := os.Create(path)
+		if err != nil {
+			return exportedReportMsg{format: format, path: path, err: err}
+		}
+		defer f.Close()
+
+		reporter, err := reporterForFormat(format, f)
+		if err != nil {
+			return exportedReportMsg{format: format, path: path, err: err}
+		}
+		if err := driveReporter(reporter, report); err != nil {
+			return exportedReportMsg{format: format, path: path, err: err}
+		}
+		return exportedReportMsg{format: format, path: path}
+	}
+}
+
 func getBenchmarkReportCmd(client pb.PrismServiceClient) tea.Cmd {
 	return func() tea.Msg {
 		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
@@ -422,6 +1046,65 @@ func getBenchmarkReportCmd(client pb.PrismServiceClient) tea.Cmd {
 	}
 }
 
+// startMetricsStreamCmd subscribes to the daemon's MetricsStream RPC, the
+// same live feed its /metrics HTTP endpoint scrapes into Prometheus
+// (index throughput, search latency percentiles, inference time, gRPC
+// handler durations, cloud transfer counters by provider) - the daemon side
+// of that instrumentation lives outside this repo; the TUI only renders
+// whatever samples arrive here.
+func startMetricsStreamCmd(client pb.PrismServiceClient, scrapeEnabled bool) tea.Cmd {
+	return func() tea.Msg {
+		ctx := context.Background()
+		stream, err := client.MetricsStream(ctx, &pb.MetricsStreamRequest{ScrapeEnabled: scrapeEnabled})
+		if err != nil {
+			return metricsStreamMsg{err: err}
+		}
+		msg, err := stream.Recv()
+		return metricsStreamMsg{stream: stream, data: msg, err: err}
+	}
+}
+
+func nextMetricsStreamCmd(stream pb.PrismService_MetricsStreamClient) tea.Cmd {
+	return func() tea.Msg {
+		msg, err := stream.Recv()
+		return metricsStreamMsg{stream: stream, data: msg, err: err}
+	}
+}
+
+// Log streaming commands
+
+func startLogStreamCmd(client pb.PrismServiceClient) tea.Cmd {
+	return func() tea.Msg {
+		ctx := context.Background()
+		stream, err := client.StreamLogs(ctx, &pb.StreamLogsRequest{})
+		if err != nil {
+			return errMsg(err)
+		}
+		entry, err := stream.Recv()
+		return logStreamMsg{stream: stream, entry: entry, err: err}
+	}
+}
+
+func nextLogStreamCmd(stream pb.PrismService_StreamLogsClient) tea.Cmd {
+	return func() tea.Msg {
+		entry, err := stream.Recv()
+		return logStreamMsg{stream: stream, entry: entry, err: err}
+	}
+}
+
+// delayedLogStreamCmd receives the next log entry after d, the delay a rate
+// limiter computed so a burst of entries can't redraw the logs view faster
+// than it paces — the same role rate.Every plays in buildkit's progressui.
+func delayedLogStreamCmd(stream pb.PrismService_StreamLogsClient, d time.Duration) tea.Cmd {
+	if d <= 0 {
+		return nextLogStreamCmd(stream)
+	}
+	return tea.Tick(d, func(time.Time) tea.Msg {
+		entry, err := stream.Recv()
+		return logStreamMsg{stream: stream, entry: entry, err: err}
+	})
+}
+
 // -- Update --
 
 func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
@@ -429,60 +1112,73 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	var cmds []tea.Cmd
 
 	switch msg := msg.(type) {
-	case connMsg:
-		m.conn = msg
-		m.client = pb.NewPrismServiceClient(m.conn)
-		// Don't switch state immediately if we want to show loading animation
-		// But for now, let's switch only if loading finishes or we connected
-		// Actually, let's keep loading until stats return or timeout
-		// For simplicity, we assume connection is the main hurdle.
-		// We will switch state in Tick if progress is done, OR if stats return.
-
-		cmds = append(cmds, getStatsCmd(m.client))
-
-	case tickMsg:
-		if m.state == stateLoading {
-			// Simulate loading progress
-			if m.loadingPercent < 1.0 {
-				m.loadingPercent += 0.02
-
-				// Simulate logs
-				if m.loadingPercent < 0.2 {
-					m.loadingLog = "Initializing Prism Daemon..."
-				} else if m.loadingPercent < 0.4 {
-					m.loadingLog = "Loading Configuration..."
-				} else if m.loadingPercent < 0.6 {
-					m.loadingLog = "Connecting to Neural Core..."
-				} else if m.loadingPercent < 0.8 {
-					m.loadingLog = "Verifying Database Integrity..."
-				} else {
-					m.loadingLog = "Starting Interface..."
-				}
-
-				cmds = append(cmds, tickCmd())
+	case connDialedMsg:
+		if msg.err != nil {
+			m.connErr = msg.err
+			m.connAttempt = msg.attempt + 1
+			m.connBackoff = nextConnBackoff(m.connBackoff)
+			cmds = append(cmds, backoffRetryCmd(m.connBackoff, m.connAttempt, msg.profile))
+			break
+		}
 
-				// Update progress bar model
-				cmd = m.progress.SetPercent(m.loadingPercent)
-				cmds = append(cmds, cmd)
-			} else {
-				// Loading done, check if we are connected
-				if m.client != nil {
-					m.state = stateHome
-				} else {
-					// Still waiting for connection...
-					m.loadingLog = "Waiting for Backend Connection..."
-					cmds = append(cmds, tickCmd())
-				}
+		m.conn = msg.conn
+		m.client = pb.NewPrismServiceClient(m.conn)
+		m.connErr = nil
+		m.connAttempt = 0
+		m.connBackoff = connBackoffMin
+		m.connState = m.conn.GetState()
+		cmds = append(cmds, watchConnStateCmd(m.conn, m.connState))
+
+	case connStateMsg:
+		m.connState = msg.state
+		switch msg.state {
+		case connectivity.Ready:
+			cmds = append(cmds, getStatsCmd(m.client))
+			if !m.healthy {
+				cmds = append(cmds, startHealthWatchCmd(msg.conn))
+			}
+			if m.state == stateLoading {
+				m.state = stateHome
 			}
+			// The previous Index stream died with the connection; re-attach
+			// to the still-running job on the daemon instead of losing track
+			// of it.
+			if m.indexing && m.indexCancel == nil && m.indexJobID != "" {
+				cmds = append(cmds, attachIndexCmd(m.client, m.indexJobID))
+			}
+		case connectivity.Shutdown:
+			m.healthy = false
+			m.client = nil
+		}
+		if m.eventBus != nil {
+			m.eventBus.Emit(notify.Event{
+				Kind:       "connection.state",
+				Severity:   notify.SeverityInfo,
+				Attributes: map[string]string{"state": msg.state.String()},
+			})
 		}
+		cmds = append(cmds, watchConnStateCmd(msg.conn, msg.state))
 
-	case retryConnectMsg:
-		cmds = append(cmds, waitForRetry())
+	case healthWatchMsg:
+		if msg.err != nil {
+			m.healthy = false
+			break
+		}
+		m.healthy = msg.resp.Status == grpc_health_v1.HealthCheckResponse_SERVING
+		cmds = append(cmds, nextHealthWatchCmd(msg.stream))
 
 	case tea.KeyMsg:
 		switch msg.String() {
 		case "ctrl+c":
 			return m, tea.Quit
+
+		case "ctrl+p":
+			if m.state != stateLoading && m.state != stateProfiles {
+				m.preProfilesState = m.state
+				m.profileCursor = m.activeProfile
+				m.state = stateProfiles
+			}
+			return m, tea.Batch(cmds...)
 		}
 
 		if m.state != stateLoading {
@@ -496,8 +1192,15 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					m.state = stateIndex
 					m.pathInput.Focus()
 				} else if m.state == stateIndex {
-					m.state = stateSettings
+					m.state = stateLogs
 					m.pathInput.Blur()
+					if !m.logStreamActive {
+						m.logStreamActive = true
+						cmds = append(cmds, startLogStreamCmd(m.client))
+					}
+				} else if m.state == stateLogs {
+					m.state = stateSettings
+					m.logFilterInput.Blur()
 					m.loadingSys = true
 					cmds = append(cmds, getSystemInfoCmd(m.client))
 				} else if m.state == stateSettings {
@@ -508,6 +1211,9 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					} else {
 						m.cloudProvider = 0
 					}
+					m.cloudAuthMode = 0
+					m.cloudFocusIndex = 0
+					m.focusCloudInput()
 					m.cloudStatus = "Switched Provider"
 				} else {
 					m.state = stateHome
@@ -519,32 +1225,28 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					if m.dashboardCursor > 0 {
 						m.dashboardCursor--
 					}
+				} else if m.state == stateProfiles {
+					if m.profileCursor > 0 {
+						m.profileCursor--
+					}
+				} else if m.state == stateSearch && !m.searchInput.Focused() {
 					if m.cursor > 0 {
 						m.cursor--
+						m.ensureCursorVisible()
+						m.refreshThumbnail(&cmds)
 					}
 				} else if m.state == stateCloudConfig {
-					// Handle focus cycle
-					if m.cloudProvider == 0 { // AWS
-						m.cloudFocusIndex--
-						if m.cloudFocusIndex < 0 {
-							m.cloudFocusIndex = 2
-						}
-						// Apply focus
-						if m.cloudFocusIndex == 0 {
-							m.awsAccessKey.Focus()
-							m.awsSecretKey.Blur()
-							m.awsRegion.Blur()
-						}
-						if m.cloudFocusIndex == 1 {
-							m.awsAccessKey.Blur()
-							m.awsSecretKey.Focus()
-							m.awsRegion.Blur()
-						}
-						if m.cloudFocusIndex == 2 {
-							m.awsAccessKey.Blur()
-							m.awsSecretKey.Blur()
-							m.awsRegion.Focus()
-						}
+					m.cloudFocusIndex--
+					m.focusCloudInput()
+				} else if m.state == stateNotifyConfig {
+					m.notifyFocusIndex--
+					if m.notifyFocusIndex < 0 {
+						m.notifyFocusIndex = 2
+					}
+					m.focusNotifyInput()
+				} else if m.state == stateBenchmark && m.benchmarkExporting {
+					if m.exportCursor > 0 {
+						m.exportCursor--
 					}
 				}
 
@@ -553,51 +1255,79 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					if m.dashboardCursor < len(m.dashboardOptions)-1 {
 						m.dashboardCursor++
 					}
+				} else if m.state == stateProfiles {
+					if m.profileCursor < len(m.profiles)-1 {
+						m.profileCursor++
+					}
+				} else if m.state == stateBenchmark && m.benchmarkExporting {
+					if m.exportCursor < len(reportFormats)-1 {
+						m.exportCursor++
+					}
 				} else if m.state == stateSearch && !m.searchInput.Focused() {
-					// Pagination Check: cursor assumes strictly within current page
-					// We calculate limit based on page size later, but for cursor movement we just clamp to page size
-					// Actually, let's keep cursor relative to the page (0-14)
-					if m.cursor < 14 && (m.page*15+m.cursor+1 < len(m.results)) {
+					if m.cursor < len(m.results)-1 {
 						m.cursor++
+						m.ensureCursorVisible()
+						m.refreshThumbnail(&cmds)
+					}
+					// Prefetch the next page once scrolling brings the cursor
+					// near the end of what's loaded, so it doesn't stall.
+					if m.searchHasMore && !m.loadingMore && len(m.results)-m.cursor <= 5 {
+						m.loadingMore = true
+						cmds = append(cmds, loadMoreResultsCmd(m.client, m.searchQuery, m.searchOffset))
 					}
 				} else if m.state == stateCloudConfig {
-					if m.cloudProvider == 0 { // AWS
-						m.cloudFocusIndex++
-						if m.cloudFocusIndex > 2 {
-							m.cloudFocusIndex = 0
-						}
-						// Apply focus
-						if m.cloudFocusIndex == 0 {
-							m.awsAccessKey.Focus()
-							m.awsSecretKey.Blur()
-							m.awsRegion.Blur()
-						}
-						if m.cloudFocusIndex == 1 {
-							m.awsAccessKey.Blur()
-							m.awsSecretKey.Focus()
-							m.awsRegion.Blur()
-						}
-						if m.cloudFocusIndex == 2 {
-							m.awsAccessKey.Blur()
-							m.awsSecretKey.Blur()
-							m.awsRegion.Focus()
-						}
+					m.cloudFocusIndex++
+					m.focusCloudInput()
+				} else if m.state == stateNotifyConfig {
+					m.notifyFocusIndex++
+					if m.notifyFocusIndex > 2 {
+						m.notifyFocusIndex = 0
+					}
+					m.focusNotifyInput()
+				}
+
+			case "pgup":
+				if m.state == stateSearch && !m.searchInput.Focused() {
+					m.resultsViewport.ViewUp()
+				}
+
+			case "pgdown":
+				if m.state == stateSearch && !m.searchInput.Focused() {
+					m.resultsViewport.ViewDown()
+					if m.searchHasMore && !m.loadingMore && m.resultsViewport.AtBottom() {
+						m.loadingMore = true
+						cmds = append(cmds, loadMoreResultsCmd(m.client, m.searchQuery, m.searchOffset))
 					}
 				}
 
-			case "left", "h":
-				if m.state == stateSearch && !m.searchInput.Focused() && m.page > 0 {
-					m.page--
+			case "home":
+				if m.state == stateSearch && !m.searchInput.Focused() {
 					m.cursor = 0
+					m.resultsViewport.GotoTop()
+					m.refreshThumbnail(&cmds)
 				}
 
-			case "right", "l":
+			case "end":
 				if m.state == stateSearch && !m.searchInput.Focused() {
-					pageSize := 15
-					if (m.page+1)*pageSize < len(m.results) {
-						m.page++
-						m.cursor = 0
+					if len(m.results) > 0 {
+						m.cursor = len(m.results) - 1
+					}
+					m.resultsViewport.GotoBottom()
+					m.refreshThumbnail(&cmds)
+					if m.searchHasMore && !m.loadingMore {
+						m.loadingMore = true
+						cmds = append(cmds, loadMoreResultsCmd(m.client, m.searchQuery, m.searchOffset))
+					}
+				}
+
+			case "/":
+				if m.state == stateLogs {
+					if m.logFilterInput.Focused() {
+						m.logFilterInput.Blur()
+					} else {
+						m.logFilterInput.Focus()
 					}
+					return m, tea.Batch(cmds...)
 				}
 
 			case "enter":
@@ -625,7 +1355,33 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 						m.licenseInput.Focus()
 					case "Quit":
 						return m, tea.Quit
+					default:
+						if p, ok := m.pluginRegistry.ByTitle(m.dashboardOptions[m.dashboardCursor]); ok {
+							m.activePlugin = p
+							m.prePluginState = m.state
+							m.state = statePlugin
+							cmds = append(cmds, p.Init())
+						}
 					}
+				} else if m.state == stateProfiles {
+					if m.profileCursor != m.activeProfile {
+						m.activeProfile = m.profileCursor
+						profile := m.profiles[m.activeProfile]
+
+						if m.conn != nil {
+							m.conn.Close()
+						}
+						m.conn = nil
+						m.client = nil
+						m.connState = connectivity.Idle
+						m.connAttempt = 0
+						m.connBackoff = connBackoffMin
+						m.connErr = nil
+						m.healthy = false
+						m.addNotification(NotifyInfo, "Switching to profile \""+profile.Name+"\"")
+						cmds = append(cmds, dialBackendCmd(0, profile))
+					}
+					m.state = m.preProfilesState
 				} else if m.state == statePro {
 					if m.licenseInput.Value() != "" && !m.activating {
 						m.activating = true
@@ -639,32 +1395,35 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					m.dbStatus = "Connecting..."
 				} else if m.state == stateSearch {
 					if !m.searchInput.Focused() && len(m.results) > 0 {
-						// Resolve absolute index
-						absIdx := m.page*15 + m.cursor
-						if absIdx < len(m.results) {
-							cmds = append(cmds, openImageCmd(m.client, m.results[absIdx].Path))
+						if m.cursor < len(m.results) {
+							cmds = append(cmds, openImageCmd(m.client, m.results[m.cursor].Path))
 						}
 					} else {
 						m.searchInput.Blur()
 						m.results = nil
 						m.searching = true
-						cmds = append(cmds, searchCmd(m.client, m.searchInput.Value()))
+						m.searchQuery = m.searchInput.Value()
+						m.searchOffset = 0
+						m.searchHasMore = false
+						cmds = append(cmds, searchCmd(m.client, m.searchQuery, 0))
 					}
 				} else if m.state == stateIndex {
 					if !m.indexing {
-						m.indexing = true
+						m.indexStatus = "Acquiring lock..."
 						m.pathInput.Blur()
-						cmds = append(cmds, startIndexCmd(m.client, m.pathInput.Value()))
+						cmds = append(cmds, acquireIndexLockCmd(m.pathInput.Value(), false))
 					}
 				} else if m.state == stateCloudConfig && !m.savingCloud {
 					// Enter in Cloud Config saves credentials
 					m.savingCloud = true
 					m.cloudStatus = "Saving..."
-					if m.cloudProvider == 0 {
-						cmds = append(cmds, saveCloudCredentialsCmd(m.client, "aws", m.awsAccessKey.Value(), m.awsSecretKey.Value(), m.awsRegion.Value(), ""))
-					} else {
-						cmds = append(cmds, saveCloudCredentialsCmd(m.client, "azure", "", "", "", m.azureConnStr.Value()))
-					}
+					cmds = append(cmds, m.saveCloudCredentialsCmd())
+				} else if m.state == stateNotifyConfig && !m.savingNotify {
+					// Enter in Notification Sinks saves the sink config and
+					// rebuilds the event bus to match.
+					m.savingNotify = true
+					m.notifyStatus = "Saving..."
+					cmds = append(cmds, saveNotificationConfigCmd(m.client, m.notifyStdoutEnabled, m.notifyWebhookURL.Value(), m.notifyWebhookToken.Value(), m.notifyUnixSocket.Value()))
 				}
 
 			case "o":
@@ -673,27 +1432,90 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					// Return early to prevent 'o' from being typed into pathInput
 					return m, tea.Batch(cmds...)
 				}
+				if m.state == stateNotifyConfig {
+					m.notifyStdoutEnabled = !m.notifyStdoutEnabled
+				}
 
 			case "b":
 				if m.state == stateSettings {
 					m.state = stateBenchmark
 				}
 
+			case "n":
+				if m.state == stateSettings {
+					m.state = stateNotifyConfig
+					m.notifyStatus = ""
+					m.notifyFocusIndex = 0
+					m.notifyWebhookURL.Focus()
+					m.notifyWebhookToken.Blur()
+					m.notifyUnixSocket.Blur()
+				}
+
 			case "c":
 				if m.state == stateSettings {
 					m.state = stateCloudConfig
 					m.cloudStatus = "" // Reset status
+					m.cloudAuthMode = 0
 					m.cloudFocusIndex = 0
-					// Auto-focus first input
-					if m.cloudProvider == 0 {
-						m.awsAccessKey.Focus()
-					} else {
-						m.azureConnStr.Focus()
+					m.focusCloudInput()
+				}
+
+			case "y":
+				// Toggle opt-in to anonymous benchmark telemetry submission.
+				if m.state == stateSettings {
+					m.telemetry.Enabled = !m.telemetry.Enabled
+					if err := saveTelemetryConfig(m.telemetry); err != nil {
+						m.addNotification(NotifyError, fmt.Sprintf("Saving telemetry preference failed: %v", err))
 					}
 				}
 
-			case "esc":
+			case "g":
+				if m.state == stateSettings {
+					m.state = stateCommunity
+					m.communityErr = nil
+					if m.communitySummary == nil && !m.loadingCommunity {
+						m.loadingCommunity = true
+						deviceClass := ""
+						if m.benchmarkReport != nil {
+							deviceClass = m.benchmarkReport.Device
+						}
+						cmds = append(cmds, fetchCommunitySummaryCmd(m.telemetry.Endpoint, "search_p95_ms", deviceClass))
+					}
+				}
 
+			case "u":
+				// Submit the last completed benchmark anonymously.
+				if m.state == stateBenchmark && m.telemetry.Enabled && m.benchmarkReport != nil && !m.submittingTelemetry && !m.benchmarkExporting {
+					m.submittingTelemetry = true
+					m.telemetryStatus = "Submitting..."
+					cmds = append(cmds, submitBenchmarkReportCmd(m.telemetry.Endpoint, m.benchmarkReport))
+				}
+
+			case "e":
+				// Open the export-format picker for the last completed
+				// benchmark.
+				if m.state == stateBenchmark && m.benchmarkReport != nil && !m.benchmarking {
+					m.benchmarkExporting = true
+					m.exportCursor = 0
+					m.exportStatus = ""
+				}
+
+			case "a":
+				// Cycle auth mode: AWS access keys -> AssumeRole -> instance
+				// profile; Azure connection string -> SAS token -> managed
+				// identity. Each mode shows a different set of cloudInputs().
+				if m.state == stateCloudConfig {
+					m.cloudAuthMode = (m.cloudAuthMode + 1) % 3
+					m.cloudFocusIndex = 0
+					m.focusCloudInput()
+					m.cloudStatus = ""
+				}
+
+			case "esc":
+
+				if m.state == stateProfiles {
+					m.state = m.preProfilesState
+				}
 				if m.state == stateSearch {
 					m.searchInput.Focus()
 				}
@@ -705,41 +1527,86 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					m.state = stateHome
 					m.pathInput.Blur()
 				}
+				if m.state == stateLogs {
+					if m.logFilterInput.Focused() {
+						m.logFilterInput.Blur()
+					} else {
+						m.state = stateHome
+					}
+				}
 				if m.state == statePro {
 					m.state = stateHome
 					m.licenseInput.Blur()
 				}
 				if m.state == stateBenchmark {
-					m.state = stateSettings
+					if m.benchmarkExporting {
+						m.benchmarkExporting = false
+					} else {
+						m.state = stateSettings
+					}
 				}
 				if m.state == stateCloudConfig {
 					m.state = stateSettings
 					m.cloudStatus = "" // clear status
 				}
+				if m.state == statePlugin {
+					m.state = m.prePluginState
+					m.activePlugin = nil
+				}
+				if m.state == stateNotifyConfig {
+					m.state = stateSettings
+					m.notifyWebhookURL.Blur()
+					m.notifyWebhookToken.Blur()
+					m.notifyUnixSocket.Blur()
+					m.notifyStatus = ""
+				}
+				if m.state == stateCommunity {
+					m.state = stateSettings
+				}
 
 			case "r":
 
 				// Re-run benchmark
-				if m.state == stateBenchmark && !m.benchmarking {
+				if m.state == stateBenchmark && !m.benchmarking && !m.benchmarkExporting {
 					m.benchmarking = true
 					m.benchmarkPhase = "starting"
 					m.benchmarkProgress = "Initializing..."
 					cmds = append(cmds, startBenchmarkCmd(m.client, "data/sample"))
 				}
 
+				// Resume a paused index job
+				if m.state == stateIndex && m.indexing && m.indexPaused {
+					cmds = append(cmds, controlIndexCmd(m.client, m.indexJobID, "resume"))
+				}
+
+			case "p":
+				if m.state == stateIndex && m.indexing && !m.indexPaused {
+					cmds = append(cmds, controlIndexCmd(m.client, m.indexJobID, "pause"))
+				}
+
+			case "x":
+				if m.state == stateIndex && m.indexing {
+					cmds = append(cmds, controlIndexCmd(m.client, m.indexJobID, "cancel"))
+				}
+
+			case "f":
+				// Force-steal a dataset lock held by another holder, via
+				// Lock.Steal (a FileLock waits for that holder's flock to be
+				// released). Gated behind devMode.
+				if m.state == stateIndex && !m.indexing && m.indexLockHolder != "" && m.devMode {
+					m.indexStatus = "Force-stealing lock..."
+					cmds = append(cmds, acquireIndexLockCmd(m.pathInput.Value(), true))
+				}
+
 			case "s":
-				if m.state == stateCloudConfig && !m.savingCloud && !m.awsAccessKey.Focused() && !m.awsSecretKey.Focused() && !m.awsRegion.Focused() && !m.azureConnStr.Focused() {
+				if m.state == stateCloudConfig && !m.savingCloud && !m.anyCloudInputFocused() {
 					m.savingCloud = true
 					m.cloudStatus = "Saving & Connecting..."
-					if m.cloudProvider == 0 {
-						cmds = append(cmds, saveCloudCredentialsCmd(m.client, "aws", m.awsAccessKey.Value(), m.awsSecretKey.Value(), m.awsRegion.Value(), ""))
-					} else {
-						cmds = append(cmds, saveCloudCredentialsCmd(m.client, "azure", "", "", "", m.azureConnStr.Value()))
-					}
+					cmds = append(cmds, m.saveCloudCredentialsCmd())
 				}
 
 			case "t":
-				if m.state == stateCloudConfig && !m.savingCloud && !m.awsAccessKey.Focused() && !m.awsSecretKey.Focused() && !m.awsRegion.Focused() && !m.azureConnStr.Focused() {
+				if m.state == stateCloudConfig && !m.savingCloud && !m.anyCloudInputFocused() {
 					m.savingCloud = true
 					m.cloudStatus = "Testing Connection..."
 					provider := "aws"
@@ -748,10 +1615,28 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					}
 					cmds = append(cmds, validateCloudCredentialsCmd(m.client, provider))
 				}
+				if m.state == stateBenchmark && !m.benchmarkExporting {
+					// Toggle whether the daemon's Prometheus scrape targets
+					// are active; takes effect next time the stream (re)opens.
+					m.metricsScrapeEnabled = !m.metricsScrapeEnabled
+				}
+
+			case "m":
+				if m.state == stateBenchmark && !m.benchmarkExporting {
+					m.metricsWatching = !m.metricsWatching
+					if m.metricsWatching {
+						m.metricsErr = nil
+						cmds = append(cmds, startMetricsStreamCmd(m.client, m.metricsScrapeEnabled))
+					}
+				}
 			}
 
 			// Handle enter in benchmark state
-			if m.state == stateBenchmark && msg.String() == "enter" && !m.benchmarking {
+			if m.state == stateBenchmark && msg.String() == "enter" && m.benchmarkExporting {
+				format := reportFormats[m.exportCursor]
+				m.exportStatus = "Writing " + format + " report..."
+				cmds = append(cmds, exportBenchmarkReportCmd(m.benchmarkReport, format))
+			} else if m.state == stateBenchmark && msg.String() == "enter" && !m.benchmarking {
 				m.benchmarking = true
 				m.benchmarkPhase = "starting"
 				m.benchmarkProgress = "Initializing..."
@@ -763,6 +1648,8 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.width = msg.Width
 		m.height = msg.Height
 		m.progress.Width = msg.Width - 20
+		m.resultsViewport.Width = msg.Width
+		m.resultsViewport.Height = msg.Height - 18
 
 	case statsMsg:
 		m.loadingStats = false
@@ -835,22 +1722,119 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		// m.dbStatus = "Error: " + msg.message
 		// }
 
+	case cloudTestMsg:
+		m.savingCloud = false
+		if msg.success {
+			m.cloudLatencyMs = msg.latencyMs
+			m.cloudRegion = msg.region
+			m.cloudStatus = fmt.Sprintf("Success: %s (%dms, %s)", msg.message, msg.latencyMs, msg.region)
+			m.addNotification(NotifySuccess, msg.message)
+		} else {
+			m.cloudStatus = "Error: " + msg.message
+			m.addNotification(NotifyError, "Cloud connection test failed")
+		}
+
+	case notifyConfigSavedMsg:
+		m.savingNotify = false
+		if msg.success {
+			m.notifyStatus = "Success: " + msg.message
+			oldBus := m.eventBus
+			m.eventBus = rebuiltEventBus(m.notifyStdoutEnabled, m.notifyUnixSocket.Value(), m.notifyWebhookURL.Value(), m.notifyWebhookToken.Value())
+			if oldBus != nil {
+				oldBus.Close()
+			}
+			m.addNotification(NotifySuccess, "Notification sinks updated")
+		} else {
+			m.notifyStatus = "Error: " + msg.message
+			m.addNotification(NotifyError, "Saving notification sinks failed")
+		}
+
 	case searchResultsMsg:
 		m.searching = false
-		m.results = msg
-		m.cursor = 0
-		m.page = 0
+		m.loadingMore = false
+		m.searchHasMore = msg.hasMore
+		m.searchOffset = msg.offset + int32(len(msg.results))
+		if msg.append {
+			m.results = append(m.results, msg.results...)
+		} else {
+			m.results = msg.results
+			m.cursor = 0
+			m.resultsViewport.GotoTop()
+		}
 		if len(m.results) > 0 {
 			m.searchInput.Blur()
-			m.addNotification(NotifyInfo, fmt.Sprintf("Found %d results", len(m.results)))
+			if !msg.append {
+				m.addNotification(NotifyInfo, fmt.Sprintf("Found %d result(s)", len(m.results)))
+				m.refreshThumbnail(&cmds)
+			}
 		} else {
 			m.searchInput.Focus()
 			m.addNotification(NotifyWarning, "No results found")
 		}
+		if m.eventBus != nil {
+			m.eventBus.Emit(notify.Event{
+				Kind:     "search.results",
+				Severity: notify.SeverityInfo,
+				Attributes: map[string]string{
+					"query":       m.searchQuery,
+					"batch_size":  fmt.Sprintf("%d", len(msg.results)),
+					"total_shown": fmt.Sprintf("%d", len(m.results)),
+					"has_more":    fmt.Sprintf("%t", m.searchHasMore),
+				},
+			})
+		}
+
+	case thumbnailMsg:
+		if msg.err != nil {
+			m.thumbLoading = false
+			break
+		}
+		m.thumbCache.put(msg.key, msg.rendered)
+		// Only adopt it if the cursor hasn't moved on to a different
+		// result since the request went out.
+		if m.state == stateSearch && m.cursor < len(m.results) && thumbnailCacheKey(m.results[m.cursor].Path) == msg.key {
+			m.thumbRendered = msg.rendered
+			m.thumbLoading = false
+		}
+
+	case indexLockMsg:
+		if msg.err != nil {
+			var lockErr *locks.ErrLocked
+			if errors.As(msg.err, &lockErr) {
+				m.indexLockHolder = lockErr.Holder.String()
+				m.indexStatus = "Locked by " + m.indexLockHolder
+			} else {
+				m.indexStatus = fmt.Sprintf("Error: %v", msg.err)
+			}
+			break
+		}
+		m.indexLockHolder = ""
+		m.indexLockRelease = msg.release
+		m.indexing = true
+		m.indexPaused = false
+		m.indexRate = 0
+		m.indexLastCurrent = 0
+		m.indexLastSample = time.Time{}
+		m.indexResumed = 0
+		m.indexDeduped = 0
+		m.indexEvents = nil
+		m.indexStages = make(map[string]*indexStageState)
+		m.indexEmbedLatencies = nil
+		if msg.resume {
+			m.indexStatus = "Resuming from manifest..."
+		}
+		cmds = append(cmds, startIndexCmd(m.client, msg.path, msg.resume))
 
 	case indexStreamMsg:
+		if msg.cancel != nil {
+			m.indexCancel = msg.cancel
+		}
 		if msg.err == io.EOF {
 			m.indexing = false
+			m.indexPaused = false
+			m.indexJobID = ""
+			m.indexCancel = nil
+			m.releaseIndexLock()
 			m.indexStatus = "Indexing complete!"
 			m.pathInput.Focus()
 			cmds = append(cmds, m.progress.SetPercent(1.0))
@@ -863,15 +1847,59 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.addNotification(NotifySuccess, summary)
 		} else if msg.err != nil {
 			m.err = msg.err
-			m.indexing = false
-			m.indexStatus = fmt.Sprintf("Error: %v", msg.err)
-			m.addNotification(NotifyError, "Indexing failed")
+			if status.Code(msg.err) == codes.Unavailable && m.indexJobID != "" {
+				// The connection dropped mid-job, not the job itself -
+				// connStateMsg's Ready handler re-attaches once the daemon
+				// is reachable again.
+				m.indexCancel = nil
+				m.indexStatus = "Connection lost, waiting to resume..."
+			} else {
+				m.indexing = false
+				m.releaseIndexLock()
+				m.indexStatus = fmt.Sprintf("Error: %v", msg.err)
+				m.addNotification(NotifyError, "Indexing failed")
+			}
 		} else {
+			if msg.data.JobId != "" {
+				m.indexJobID = msg.data.JobId
+			}
 			m.indexCurrent = msg.data.Current
 			m.indexTotal = msg.data.Total
 			m.indexStatus = msg.data.StatusMessage
 			m.indexSkipped = msg.data.Skipped
 			m.indexETA = msg.data.EtaSeconds
+			m.indexResumed = msg.data.Resumed
+			m.indexDeduped = msg.data.Deduped
+
+			for _, ev := range msg.data.Events {
+				m.pushIndexEvent(indexEvent{
+					at:       time.Now(),
+					kind:     ev.Kind,
+					stage:    ev.Stage,
+					path:     ev.Path,
+					bytes:    ev.Bytes,
+					embedMs:  ev.EmbedMs,
+					reason:   ev.Reason,
+					attempt:  ev.Attempt,
+					duration: time.Duration(ev.DurationMs) * time.Millisecond,
+				})
+			}
+
+			now := time.Now()
+			if !m.indexLastSample.IsZero() {
+				if dt := now.Sub(m.indexLastSample).Seconds(); dt > 0 {
+					inst := float64(m.indexCurrent-m.indexLastCurrent) / dt
+					const emaAlpha = 0.3
+					if m.indexRate == 0 {
+						m.indexRate = inst
+					} else {
+						m.indexRate = emaAlpha*inst + (1-emaAlpha)*m.indexRate
+					}
+				}
+			}
+			m.indexLastSample = now
+			m.indexLastCurrent = m.indexCurrent
+
 			pct := 0.0
 			if m.indexTotal > 0 {
 				pct = float64(m.indexCurrent) / float64(m.indexTotal)
@@ -879,6 +1907,43 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			cmd = m.progress.SetPercent(pct)
 			cmds = append(cmds, cmd)
 			cmds = append(cmds, nextIndexCmd(msg.stream))
+
+			if m.eventBus != nil {
+				m.eventBus.Emit(notify.Event{
+					Kind:          "index.progress",
+					Severity:      notify.SeverityInfo,
+					CorrelationID: m.indexJobID,
+					Attributes: map[string]string{
+						"current": fmt.Sprintf("%d", m.indexCurrent),
+						"total":   fmt.Sprintf("%d", m.indexTotal),
+						"skipped": fmt.Sprintf("%d", m.indexSkipped),
+					},
+				})
+			}
+		}
+
+	case indexControlMsg:
+		if !msg.success {
+			m.addNotification(NotifyError, "Index control failed: "+msg.message)
+			break
+		}
+		switch msg.action {
+		case "pause":
+			m.indexPaused = true
+		case "resume":
+			m.indexPaused = false
+		case "cancel":
+			m.indexing = false
+			m.indexPaused = false
+			m.indexJobID = ""
+			if m.indexCancel != nil {
+				m.indexCancel()
+				m.indexCancel = nil
+			}
+			m.releaseIndexLock()
+		}
+		if msg.message != "" {
+			m.indexStatus = msg.message
 		}
 
 	case benchmarkProgressMsg:
@@ -896,10 +1961,87 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.benchmarkProgress = msg.data.Message
 			cmds = append(cmds, nextBenchmarkCmd(msg.stream))
 		}
+		if m.eventBus != nil {
+			m.eventBus.Emit(notify.Event{
+				Kind:     "benchmark.phase",
+				Severity: notify.SeverityInfo,
+				Attributes: map[string]string{
+					"phase":   m.benchmarkPhase,
+					"message": m.benchmarkProgress,
+				},
+			})
+		}
 
 	case benchmarkReportMsg:
 		m.benchmarkReport = msg
 
+	case exportedReportMsg:
+		m.benchmarkExporting = false
+		if msg.err != nil {
+			m.exportStatus = fmt.Sprintf("Export failed: %v", msg.err)
+			m.addNotification(NotifyError, "Benchmark export failed")
+		} else {
+			m.exportStatus = "Wrote " + msg.path
+			m.addNotification(NotifySuccess, "Exported benchmark as "+msg.format)
+		}
+
+	case telemetrySubmittedMsg:
+		m.submittingTelemetry = false
+		if msg.err != nil {
+			m.telemetryStatus = fmt.Sprintf("Submission failed: %v", msg.err)
+			m.addNotification(NotifyError, "Benchmark submission failed")
+		} else {
+			m.telemetryStatus = "Thanks for contributing!"
+			m.addNotification(NotifySuccess, "Benchmark submitted anonymously")
+		}
+
+	case communitySummaryMsg:
+		m.loadingCommunity = false
+		if msg.err != nil {
+			m.communityErr = msg.err
+		} else {
+			summary := msg.summary
+			m.communitySummary = &summary
+		}
+
+	case metricsStreamMsg:
+		if msg.err != nil {
+			m.metricsWatching = false
+			if msg.err != io.EOF {
+				m.metricsErr = msg.err
+			}
+			break
+		}
+		for _, point := range msg.data.Metrics {
+			series := m.metricsSeries[point.Name]
+			series = append(series, point.Value)
+			if len(series) > metricsStreamSize {
+				series = series[len(series)-metricsStreamSize:]
+			}
+			m.metricsSeries[point.Name] = series
+			m.metricsUnits[point.Name] = point.Unit
+		}
+		// Only keep pulling samples while the user still has the Diagnostics
+		// watch turned on; toggling 'm' off lets this stream quietly drain.
+		if m.metricsWatching {
+			cmds = append(cmds, nextMetricsStreamCmd(msg.stream))
+		}
+
+	case logStreamMsg:
+		if msg.err != nil {
+			m.logStreamActive = false
+			if msg.err != io.EOF {
+				m.addNotification(NotifyError, "Log stream disconnected")
+			}
+		} else {
+			m.logs = append(m.logs, msg.entry)
+			if len(m.logs) > logRingSize {
+				m.logs = m.logs[len(m.logs)-logRingSize:]
+			}
+			d := m.logLimiter.Reserve().Delay()
+			cmds = append(cmds, delayedLogStreamCmd(msg.stream, d))
+		}
+
 	case errMsg:
 		m.err = msg
 		m.loadingStats = false
@@ -925,6 +2067,10 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.pathInput, cmd = m.pathInput.Update(msg)
 		cmds = append(cmds, cmd)
 	}
+	if m.state == stateLogs {
+		m.logFilterInput, cmd = m.logFilterInput.Update(msg)
+		cmds = append(cmds, cmd)
+	}
 	if m.state == stateConnectDB {
 		m.dbInput, cmd = m.dbInput.Update(msg)
 		cmds = append(cmds, cmd)
@@ -934,29 +2080,25 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		cmds = append(cmds, cmd)
 	}
 	if m.state == stateCloudConfig {
-		// Update cloud inputs
-		if m.cloudProvider == 0 {
-			m.awsAccessKey, cmd = m.awsAccessKey.Update(msg)
-			if cmd != nil {
-				cmds = append(cmds, cmd)
-			}
-
-			m.awsSecretKey, cmd = m.awsSecretKey.Update(msg)
-			if cmd != nil {
-				cmds = append(cmds, cmd)
-			}
-
-			m.awsRegion, cmd = m.awsRegion.Update(msg)
-			if cmd != nil {
-				cmds = append(cmds, cmd)
-			}
-		} else {
-			m.azureConnStr, cmd = m.azureConnStr.Update(msg)
+		// Update whichever cloud inputs are visible for the current
+		// provider/authMode - only the focused one actually consumes
+		// keystrokes, but Update must still be called on each to keep its
+		// cursor blink/blur state correct.
+		for _, in := range m.cloudInputs() {
+			*in, cmd = in.Update(msg)
 			if cmd != nil {
 				cmds = append(cmds, cmd)
 			}
 		}
 	}
+	if m.state == stateNotifyConfig {
+		m.notifyWebhookURL, cmd = m.notifyWebhookURL.Update(msg)
+		cmds = append(cmds, cmd)
+		m.notifyWebhookToken, cmd = m.notifyWebhookToken.Update(msg)
+		cmds = append(cmds, cmd)
+		m.notifyUnixSocket, cmd = m.notifyUnixSocket.Update(msg)
+		cmds = append(cmds, cmd)
+	}
 
 	// Progress Bar Update
 	var progModel tea.Model
@@ -968,6 +2110,18 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	m.spinner, cmd = m.spinner.Update(msg)
 	cmds = append(cmds, cmd)
 
+	m.banner, cmd = m.banner.Update(msg)
+	cmds = append(cmds, cmd)
+
+	if m.state == statePlugin && m.activePlugin != nil {
+		m.activePlugin, cmd = m.activePlugin.Update(msg)
+		cmds = append(cmds, cmd)
+	}
+
+	if m.state == stateSearch && len(m.results) > 0 {
+		m.syncResultsViewport()
+	}
+
 	return m, tea.Batch(cmds...)
 }
 
@@ -975,13 +2129,13 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 func (m model) View() string {
 	if m.state == stateLoading {
-		return docStyle.Render(viewLoading(m))
+		return m.styles.Doc.Render(viewLoading(m))
 	}
 
 	var doc strings.Builder
 
 	// 1. Header (Tabs)
-	tabs := []string{"Dashboard", "Search", "Index", "Settings"}
+	tabs := []string{"Dashboard", "Search", "Index", "Logs", "Settings"}
 	var renderedTabs []string
 	for i, t := range tabs {
 		isActive := false
@@ -991,18 +2145,20 @@ func (m model) View() string {
 			isActive = true
 		} else if m.state == stateIndex && i == 2 {
 			isActive = true
-		} else if m.state == stateSettings && i == 3 {
+		} else if m.state == stateLogs && i == 3 {
+			isActive = true
+		} else if m.state == stateSettings && i == 4 {
 			isActive = true
 		}
 
 		if isActive {
-			renderedTabs = append(renderedTabs, activeTabStyle.Render(t))
+			renderedTabs = append(renderedTabs, m.styles.ActiveTab.Render(t))
 		} else {
-			renderedTabs = append(renderedTabs, tabStyle.Render(t))
+			renderedTabs = append(renderedTabs, m.styles.Tab.Render(t))
 		}
 	}
 	tabRow := lipgloss.JoinHorizontal(lipgloss.Bottom, renderedTabs...)
-	gap := tabGapStyle.Render(strings.Repeat(" ", max(0, m.width-lipgloss.Width(tabRow)-2)))
+	gap := m.styles.TabGap.Render(strings.Repeat(" ", max(0, m.width-lipgloss.Width(tabRow)-2)))
 	header := lipgloss.JoinHorizontal(lipgloss.Bottom, tabRow, gap)
 	doc.WriteString(header + "\n\n")
 
@@ -1015,6 +2171,8 @@ func (m model) View() string {
 		mainContent = viewSearch(m)
 	case stateIndex:
 		mainContent = viewIndex(m)
+	case stateLogs:
+		mainContent = viewLogs(m)
 	case stateConnectDB:
 		mainContent = viewConnectDB(m)
 	case stateSettings:
@@ -1025,6 +2183,14 @@ func (m model) View() string {
 		mainContent = viewBenchmark(m)
 	case stateCloudConfig:
 		mainContent = viewCloudConfig(m)
+	case stateProfiles:
+		mainContent = viewProfiles(m)
+	case stateNotifyConfig:
+		mainContent = viewNotifyConfig(m)
+	case stateCommunity:
+		mainContent = viewCommunity(m)
+	case statePlugin:
+		mainContent = m.activePlugin.View()
 	}
 
 	// Sidebar Content
@@ -1033,41 +2199,53 @@ func (m model) View() string {
 	// Combine Main and Sidebar
 	splitView := lipgloss.JoinHorizontal(lipgloss.Top,
 		lipgloss.NewStyle().Width(m.width-35).Render(mainContent),
-		sidebarStyle.Width(30).Height(m.height-12).Render(sidebar),
+		m.styles.Sidebar.Width(30).Height(m.height-12).Render(sidebar),
 	)
 	doc.WriteString(splitView)
 
 	// 3. Footer / Help Bar
-	statusText := "● OFFLINE"
-	if m.client != nil {
-		statusText = "● ONLINE"
-	}
-	statusStyle := lipgloss.NewStyle().Foreground(errorColor).Bold(true)
-	if m.client != nil {
-		statusStyle = lipgloss.NewStyle().Foreground(successColor).Bold(true)
-	}
+	statusText, statusStyle := m.connectionLabel()
+	profileName := m.styles.Subtle.Render("[" + m.profiles[m.activeProfile].Name + "]  ")
 
-	helpLeft := subtleStyle.Render(" "+asciiTexture) + "\n " + subtleStyle.Render(" TAB: CYCLE • ↑/↓: NAVIGATE • ENTER: SELECT • CTRL+C: QUIT")
-	helpRight := "\n" + statusStyle.Render(statusText+"  ")
+	helpLeft := m.styles.Subtle.Render(" "+m.styles.asciiTexture()) + "\n " + m.styles.Subtle.Render(" TAB: CYCLE • ↑/↓: NAVIGATE • ENTER: SELECT • CTRL+P: PROFILES • CTRL+C: QUIT")
+	if m.state == statePlugin && m.activePlugin != nil {
+		if keys := m.activePlugin.KeyBindings(); len(keys) > 0 {
+			helpLeft += m.styles.Subtle.Render(" • " + strings.Join(keys, " • "))
+		}
+	}
+	helpRight := "\n" + profileName + statusStyle.Render(statusText+"  ")
 
 	footerGap := strings.Repeat(" ", max(0, m.width-lipgloss.Width(helpLeft)-lipgloss.Width(helpRight)-1))
 	footer := lipgloss.JoinHorizontal(lipgloss.Bottom, helpLeft, footerGap, helpRight)
 
 	doc.WriteString("\n" + footer)
 
-	return docStyle.Render(doc.String())
+	return m.styles.Doc.Render(doc.String())
+}
+
+// connectionLabel renders the short status indicator shown in the footer
+// and sidebar: ONLINE once conn is Ready and the Health/Watch stream has
+// reported SERVING, RECONNECTING while a client exists but isn't Ready
+// (gRPC's own backoff is re-dialing under the hood), and OFFLINE before
+// the first successful dial.
+func (m model) connectionLabel() (string, lipgloss.Style) {
+	switch {
+	case m.client != nil && m.connState == connectivity.Ready && m.healthy:
+		return "● ONLINE", m.styles.Success
+	case m.client != nil:
+		return "● RECONNECTING", m.styles.Warning
+	default:
+		return "● OFFLINE", m.styles.Error
+	}
 }
 
 func viewSidebar(m model) string {
 	var sections []string
 
 	// Section 1: System Health
-	healthStatus := "Stable"
-	if m.client == nil {
-		healthStatus = "Disconnected"
-	}
+	healthStatus, _ := m.connectionLabel()
 	sections = append(sections,
-		headerStyle.Render("NEURAL CORE"),
+		m.styles.Header.Render("NEURAL CORE"),
 		fmt.Sprintf("Status: %s", healthStatus),
 		fmt.Sprintf("Device: %s", "GPU/MPS"),
 	)
@@ -1075,40 +2253,42 @@ func viewSidebar(m model) string {
 	// Section 2: Contextual Info
 	if m.state == stateSearch && len(m.results) > 0 && m.cursor < len(m.results) {
 		selected := m.results[m.cursor]
-		sections = append(sections,
-			"\n"+headerStyle.Render("SELECTED FRAME"),
-			fmt.Sprintf("Match: %.1f%%", selected.Confidence*100),
-			fmt.Sprintf("Res: %s", selected.Resolution),
-			fmt.Sprintf("Size: %s", selected.FileSize),
-		)
-		// Show match type
-		if selected.MatchType != "" {
-			matchType := "Full Image"
-			if selected.MatchType == "object_crop" {
-				matchType = "Object Crop"
-			}
-			sections = append(sections, fmt.Sprintf("Type: %s", matchType))
+		sections = append(sections, "\n"+m.styles.Header.Render("SELECTED FRAME"))
+		switch {
+		case m.thumbRendered != "":
+			sections = append(sections, m.thumbRendered)
+		case m.thumbLoading:
+			sections = append(sections, m.styles.Subtle.Render(m.spinner.View()+" loading preview..."))
 		}
-		// Show detected objects
-		if len(selected.DetectedObjects) > 0 {
-			sections = append(sections, "\n"+headerStyle.Render("DETECTED"))
-			for _, obj := range selected.DetectedObjects {
-				sections = append(sections, logTextStyle.Render("• "+obj))
+		// describeFrame builds the same label/value pairs `prism describe
+		// frame` renders via tabwriter - this sidebar just re-styles them.
+		for _, section := range describeFrame(selected) {
+			if section.Title != "FRAME" {
+				sections = append(sections, "\n"+m.styles.Header.Render(strings.ToUpper(section.Title)))
+			}
+			for _, pair := range section.Pairs {
+				if pair[0] == "Path" {
+					continue // the results list already shows each frame's path
+				}
+				sections = append(sections, fmt.Sprintf("%s: %s", pair[0], pair[1]))
+			}
+			for _, item := range section.List {
+				sections = append(sections, m.styles.LogText.Render("• "+item))
 			}
 		}
 	} else if m.stats != nil {
 		sections = append(sections,
-			"\n"+headerStyle.Render("DATASET STATS"),
+			"\n"+m.styles.Header.Render("DATASET STATS"),
 			fmt.Sprintf("Frames: %d", m.stats.TotalFrames),
 			fmt.Sprintf("Vectors: %d", m.stats.TotalEmbeddings),
 		)
 	}
 
 	// Section 3: Notifications (dynamic)
-	sections = append(sections, "\n"+headerStyle.Render("NOTIFICATIONS"))
+	sections = append(sections, "\n"+m.styles.Header.Render("NOTIFICATIONS"))
 
 	if len(m.notifications) == 0 {
-		sections = append(sections, logTextStyle.Render("No notifications"))
+		sections = append(sections, m.styles.LogText.Render("No notifications"))
 	} else {
 		// Show last 5 notifications (most recent first)
 		start := len(m.notifications) - 5
@@ -1121,16 +2301,16 @@ func viewSidebar(m model) string {
 			var icon string
 			switch n.Type {
 			case NotifySuccess:
-				style = lipgloss.NewStyle().Foreground(successColor)
+				style = lipgloss.NewStyle().Foreground(m.styles.Theme.Success)
 				icon = "✓"
 			case NotifyError:
-				style = lipgloss.NewStyle().Foreground(errorColor)
+				style = lipgloss.NewStyle().Foreground(m.styles.Theme.Error)
 				icon = "✗"
 			case NotifyWarning:
 				style = lipgloss.NewStyle().Foreground(lipgloss.Color("#FFB800"))
 				icon = "⚠"
 			default:
-				style = logTextStyle
+				style = m.styles.LogText
 				icon = "•"
 			}
 			timeStr := formatRelativeTime(n.Timestamp)
@@ -1138,7 +2318,7 @@ func viewSidebar(m model) string {
 			if len(msg) > 22 {
 				msg = msg[:19] + "..."
 			}
-			sections = append(sections, style.Render(fmt.Sprintf("%s %s", icon, msg))+" "+subtleStyle.Render(timeStr))
+			sections = append(sections, style.Render(fmt.Sprintf("%s %s", icon, msg))+" "+m.styles.Subtle.Render(timeStr))
 		}
 	}
 
@@ -1147,145 +2327,311 @@ func viewSidebar(m model) string {
 
 // -- Sub-Views --
 
+// viewLoading shows the real dial/connectivity state rather than a
+// simulated progress bar: the spinner spins until the first successful
+// dial, and the status line reports the connectivity.State, the retry
+// count, and the last dial error once one has occurred.
 func viewLoading(m model) string {
-	// 50% width progress bar
-	m.progress.Width = 40
+	status := connStateLabel(m.connState)
+	if m.client == nil && m.connAttempt > 0 {
+		status = fmt.Sprintf("Retrying in %s (attempt %d)", m.connBackoff.Round(time.Millisecond), m.connAttempt)
+	}
+
+	lines := []string{status}
+	if m.connErr != nil {
+		lines = append(lines, m.styles.Subtle.Render(m.connErr.Error()))
+	}
 
 	content := lipgloss.JoinVertical(
 		lipgloss.Center,
-		RenderGradientBanner(),
+		m.banner.View(),
 		"\n",
-		m.progress.View(),
-		logTextStyle.Render(m.loadingLog),
+		m.spinner.View()+" "+m.styles.LogText.Render(strings.Join(lines, "\n")),
 	)
 
 	// Center the content in the window
 	return lipgloss.Place(
 		m.width, m.height,
 		lipgloss.Center, lipgloss.Center,
-		loadingBoxStyle.Render(content),
+		m.styles.LoadingBox.Render(content),
 	)
 }
 
+// connStateLabel renders a connectivity.State in the same plain-English
+// register the rest of the loading screen used for its simulated log
+// lines ("Connecting to Neural Core...", etc).
+func connStateLabel(s connectivity.State) string {
+	switch s {
+	case connectivity.Connecting:
+		return "Connecting to Neural Core..."
+	case connectivity.Ready:
+		return "Connected. Starting Interface..."
+	case connectivity.TransientFailure:
+		return "Connection lost, retrying..."
+	case connectivity.Shutdown:
+		return "Connection shut down."
+	default:
+		return "Initializing..."
+	}
+}
+
 func viewSettings(m model) string {
 	var content strings.Builder
-	content.WriteString(headerBoxStyle.Render("MODULAR NEURAL COMPONENTS") + "\n\n")
+	content.WriteString(m.styles.HeaderBox.Render("MODULAR NEURAL COMPONENTS") + "\n\n")
 
 	if m.loadingSys {
 		content.WriteString("  " + m.spinner.View() + " Querying component hierarchy...")
 	} else if m.sysInfo != nil {
-		content.WriteString(fmt.Sprintf("%s %s\n", statLabelStyle.Render("CORE DEVICE:"), keywordStyle.Render(m.sysInfo.Device)))
-		content.WriteString(fmt.Sprintf("%s %s\n", statLabelStyle.Render("TRANSFORMER:"), m.sysInfo.SiglipModel))
-		content.WriteString(fmt.Sprintf("%s %s\n", statLabelStyle.Render("DETECTOR:"), m.sysInfo.YoloModel))
-		content.WriteString(fmt.Sprintf("%s %s\n", statLabelStyle.Render("VERSION:"), m.sysInfo.BackendVersion))
-		content.WriteString(fmt.Sprintf("%s %d threads\n", statLabelStyle.Render("THREADS:"), m.sysInfo.CpuCount))
-		content.WriteString(fmt.Sprintf("%s %s\n", statLabelStyle.Render("MEMORY:"), m.sysInfo.MemoryUsage))
+		content.WriteString(fmt.Sprintf("%s %s\n", m.styles.StatLabel.Render("CORE DEVICE:"), m.styles.Keyword.Render(m.sysInfo.Device)))
+		content.WriteString(fmt.Sprintf("%s %s\n", m.styles.StatLabel.Render("TRANSFORMER:"), m.sysInfo.SiglipModel))
+		content.WriteString(fmt.Sprintf("%s %s\n", m.styles.StatLabel.Render("DETECTOR:"), m.sysInfo.YoloModel))
+		content.WriteString(fmt.Sprintf("%s %s\n", m.styles.StatLabel.Render("VERSION:"), m.sysInfo.BackendVersion))
+		content.WriteString(fmt.Sprintf("%s %d threads\n", m.styles.StatLabel.Render("THREADS:"), m.sysInfo.CpuCount))
+		content.WriteString(fmt.Sprintf("%s %s\n", m.styles.StatLabel.Render("MEMORY:"), m.sysInfo.MemoryUsage))
 	} else {
-		content.WriteString(subtleStyle.Render("Component telemetry unavailable."))
+		content.WriteString(m.styles.Subtle.Render("Component telemetry unavailable."))
 	}
 
 	// License Status Section
-	content.WriteString("\n\n" + headerBoxStyle.Render("LICENSE STATUS") + "\n\n")
+	content.WriteString("\n\n" + m.styles.HeaderBox.Render("LICENSE STATUS") + "\n\n")
 	if m.sysInfo != nil && m.sysInfo.IsPro {
-		content.WriteString(fmt.Sprintf("%s %s\n", statLabelStyle.Render("TIER:"), successStyle.Render("PRO")))
-		content.WriteString(fmt.Sprintf("%s %s\n", statLabelStyle.Render("STATUS:"), successStyle.Render("ACTIVE")))
+		content.WriteString(fmt.Sprintf("%s %s\n", m.styles.StatLabel.Render("TIER:"), m.styles.Success.Render("PRO")))
+		content.WriteString(fmt.Sprintf("%s %s\n", m.styles.StatLabel.Render("STATUS:"), m.styles.Success.Render("ACTIVE")))
 		if m.sysInfo.LicenseEmail != "" {
-			content.WriteString(fmt.Sprintf("%s %s\n", statLabelStyle.Render("ACCOUNT:"), m.sysInfo.LicenseEmail))
+			content.WriteString(fmt.Sprintf("%s %s\n", m.styles.StatLabel.Render("ACCOUNT:"), m.sysInfo.LicenseEmail))
 		}
 		if m.sysInfo.LicenseExpires != "" {
-			content.WriteString(fmt.Sprintf("%s %s\n", statLabelStyle.Render("EXPIRES:"), m.sysInfo.LicenseExpires))
+			content.WriteString(fmt.Sprintf("%s %s\n", m.styles.StatLabel.Render("EXPIRES:"), m.sysInfo.LicenseExpires))
 		}
-		content.WriteString(fmt.Sprintf("%s %s\n", statLabelStyle.Render("LIMITS:"), "Unlimited Indexing"))
+		content.WriteString(fmt.Sprintf("%s %s\n", m.styles.StatLabel.Render("LIMITS:"), "Unlimited Indexing"))
 	} else {
-		content.WriteString(fmt.Sprintf("%s %s\n", statLabelStyle.Render("TIER:"), subtleStyle.Render("Community Edition")))
-		content.WriteString(fmt.Sprintf("%s %s\n", statLabelStyle.Render("STATUS:"), successStyle.Render("All Features Unlocked")))
-		content.WriteString(fmt.Sprintf("%s %s\n", statLabelStyle.Render("LIMITS:"), "Unlimited indexing"))
+		content.WriteString(fmt.Sprintf("%s %s\n", m.styles.StatLabel.Render("TIER:"), m.styles.Subtle.Render("Community Edition")))
+		content.WriteString(fmt.Sprintf("%s %s\n", m.styles.StatLabel.Render("STATUS:"), m.styles.Success.Render("All Features Unlocked")))
+		content.WriteString(fmt.Sprintf("%s %s\n", m.styles.StatLabel.Render("LIMITS:"), "Unlimited indexing"))
 	}
 
 	// Advanced Section
-	content.WriteString("\n\n" + headerBoxStyle.Render("ADVANCED") + "\n\n")
-	content.WriteString("  " + keywordStyle.Render("[b]") + " Benchmarks & Diagnostics\n")
-	content.WriteString("  " + keywordStyle.Render("[c]") + " Configure Cloud Credentials (Pro)\n")
-	content.WriteString(subtleStyle.Render("  Press 'b' or 'c' to access tools\n"))
+	content.WriteString("\n\n" + m.styles.HeaderBox.Render("ADVANCED") + "\n\n")
+	content.WriteString("  " + m.styles.Keyword.Render("[b]") + " Benchmarks & Diagnostics\n")
+	content.WriteString("  " + m.styles.Keyword.Render("[c]") + " Configure Cloud Credentials (Pro)\n")
+	content.WriteString("  " + m.styles.Keyword.Render("[n]") + " Notification Sinks (webhook/stdout/socket)\n")
+	content.WriteString("  " + m.styles.Keyword.Render("[g]") + " Community Benchmarks\n")
+	content.WriteString(m.styles.Subtle.Render("  Press 'b', 'c', 'n' or 'g' to access tools\n"))
+
+	// Telemetry opt-in Section
+	content.WriteString("\n" + m.styles.HeaderBox.Render("ANONYMOUS TELEMETRY") + "\n\n")
+	if m.telemetry.Enabled {
+		content.WriteString(fmt.Sprintf("%s %s\n", m.styles.StatLabel.Render("BENCHMARK SUBMISSION:"), m.styles.Success.Render("opted in")))
+	} else {
+		content.WriteString(fmt.Sprintf("%s %s\n", m.styles.StatLabel.Render("BENCHMARK SUBMISSION:"), m.styles.Subtle.Render("opted out")))
+	}
+	content.WriteString(fmt.Sprintf("%s %s\n", m.styles.StatLabel.Render("AGGREGATOR:"), m.telemetry.Endpoint))
+	content.WriteString(m.styles.Subtle.Render("  Press 'y' to toggle - results are only sent after you confirm on a completed benchmark\n"))
 
 	return lipgloss.NewStyle().Padding(1, 2).Render(content.String())
 }
 
+// sparklineBlocks are the eighth-block glyphs renderSparkline quantizes
+// samples into, low to high.
+var sparklineBlocks = []rune("▁▂▃▄▅▆▇█")
+
+// renderSparkline renders samples as a single line of block glyphs scaled
+// to their own min/max, the same shape categraf's embedded scrape manager
+// uses for its terminal dashboards.
+func renderSparkline(samples []float64) string {
+	if len(samples) == 0 {
+		return ""
+	}
+	min, max := samples[0], samples[0]
+	for _, v := range samples {
+		if v < min {
+			min = v
+		}
+		if v > max {
+			max = v
+		}
+	}
+
+	var out strings.Builder
+	span := max - min
+	for _, v := range samples {
+		idx := len(sparklineBlocks) - 1
+		if span > 0 {
+			idx = int((v - min) / span * float64(len(sparklineBlocks)-1))
+		}
+		out.WriteRune(sparklineBlocks[idx])
+	}
+	return out.String()
+}
+
 func viewBenchmark(m model) string {
 	var content strings.Builder
-	content.WriteString(headerBoxStyle.Render("BENCHMARKS & DIAGNOSTICS") + "\n\n")
+	content.WriteString(m.styles.HeaderBox.Render("BENCHMARKS & DIAGNOSTICS") + "\n\n")
+
+	if m.benchmarkExporting {
+		content.WriteString(m.styles.Header.Render("EXPORT REPORT AS") + "\n\n")
+		for i, format := range reportFormats {
+			cursor := "  "
+			if i == m.exportCursor {
+				cursor = m.styles.Keyword.Render("> ")
+			}
+			content.WriteString(fmt.Sprintf("%s%s (.%s)\n", cursor, format, reportFormatExt(format)))
+		}
+		content.WriteString("\n" + m.styles.Subtle.Render("Press ENTER to write, ESC to cancel"))
+		return lipgloss.NewStyle().Padding(1, 2).Render(content.String())
+	}
 
 	if m.benchmarking {
 		content.WriteString(fmt.Sprintf("  %s Running benchmark...\n\n", m.spinner.View()))
-		content.WriteString(fmt.Sprintf("  %s %s\n", statLabelStyle.Render("PHASE:"), keywordStyle.Render(m.benchmarkPhase)))
-		content.WriteString(fmt.Sprintf("  %s %s\n", statLabelStyle.Render("STATUS:"), m.benchmarkProgress))
+		content.WriteString(fmt.Sprintf("  %s %s\n", m.styles.StatLabel.Render("PHASE:"), m.styles.Keyword.Render(m.benchmarkPhase)))
+		content.WriteString(fmt.Sprintf("  %s %s\n", m.styles.StatLabel.Render("STATUS:"), m.benchmarkProgress))
 	} else if m.benchmarkReport != nil && m.benchmarkReport.Timestamp != "" {
 		// Display last report
-		content.WriteString(fmt.Sprintf("%s %s\n", statLabelStyle.Render("TIMESTAMP:"), m.benchmarkReport.Timestamp))
-		content.WriteString(fmt.Sprintf("%s %s\n", statLabelStyle.Render("DEVICE:"), keywordStyle.Render(m.benchmarkReport.Device)))
-		content.WriteString(fmt.Sprintf("%s %s\n", statLabelStyle.Render("OS:"), m.benchmarkReport.Os))
-		content.WriteString(fmt.Sprintf("%s %s\n\n", statLabelStyle.Render("VERSION:"), m.benchmarkReport.PrismVersion))
+		content.WriteString(fmt.Sprintf("%s %s\n", m.styles.StatLabel.Render("TIMESTAMP:"), m.benchmarkReport.Timestamp))
+		content.WriteString(fmt.Sprintf("%s %s\n", m.styles.StatLabel.Render("DEVICE:"), m.styles.Keyword.Render(m.benchmarkReport.Device)))
+		content.WriteString(fmt.Sprintf("%s %s\n", m.styles.StatLabel.Render("OS:"), m.benchmarkReport.Os))
+		content.WriteString(fmt.Sprintf("%s %s\n\n", m.styles.StatLabel.Render("VERSION:"), m.benchmarkReport.PrismVersion))
 
 		// Indexing Metrics
-		content.WriteString(headerStyle.Render("INDEXING METRICS") + "\n")
+		content.WriteString(m.styles.Header.Render("INDEXING METRICS") + "\n")
 		for _, metric := range m.benchmarkReport.IndexingMetrics {
 			content.WriteString(fmt.Sprintf("  %s: %.2f %s\n", metric.Name, metric.Value, metric.Unit))
 		}
 
 		// Search Metrics
-		content.WriteString("\n" + headerStyle.Render("SEARCH METRICS") + "\n")
+		content.WriteString("\n" + m.styles.Header.Render("SEARCH METRICS") + "\n")
 		for _, metric := range m.benchmarkReport.SearchMetrics {
 			content.WriteString(fmt.Sprintf("  %s: %.2f %s\n", metric.Name, metric.Value, metric.Unit))
 		}
 
 		// System Metrics
-		content.WriteString("\n" + headerStyle.Render("SYSTEM METRICS") + "\n")
+		content.WriteString("\n" + m.styles.Header.Render("SYSTEM METRICS") + "\n")
 		for _, metric := range m.benchmarkReport.SystemMetrics {
 			content.WriteString(fmt.Sprintf("  %s: %.2f %s\n", metric.Name, metric.Value, metric.Unit))
 		}
 
-		content.WriteString("\n" + subtleStyle.Render("Press 'r' to run again, 'e' to export, ESC to go back"))
+		content.WriteString("\n" + m.styles.Header.Render("COMMUNITY") + "\n")
+		if !m.telemetry.Enabled {
+			content.WriteString(m.styles.Subtle.Render("  Anonymous submission is opted out - enable it in Settings ('y') to contribute.\n"))
+		} else if m.submittingTelemetry {
+			content.WriteString(fmt.Sprintf("  %s Submitting...\n", m.spinner.View()))
+		} else if m.telemetryStatus != "" {
+			content.WriteString("  " + m.telemetryStatus + "\n")
+		} else {
+			content.WriteString("  Press " + m.styles.Keyword.Render("'u'") + " to submit this result anonymously.\n")
+		}
+
+		if m.exportStatus != "" {
+			content.WriteString("\n" + m.exportStatus + "\n")
+		}
+		content.WriteString("\n" + m.styles.Subtle.Render("Press 'r' to run again, 'e' to export, ESC to go back"))
+	} else {
+		content.WriteString(m.styles.Subtle.Render("No benchmark results yet.") + "\n\n")
+		content.WriteString("Press " + m.styles.Keyword.Render("ENTER") + " to run a benchmark on sample data.\n")
+		content.WriteString(m.styles.Subtle.Render("This will index data/sample and run standard queries.\n"))
+		content.WriteString("\n" + m.styles.Subtle.Render("Press ESC to go back"))
+	}
+
+	// Live Diagnostics: sparklines off the MetricsStream RPC, independent of
+	// whether a one-shot benchmark has ever been run.
+	content.WriteString("\n\n" + m.styles.Header.Render("LIVE DIAGNOSTICS") + "\n")
+	scrapeState := "disabled"
+	if m.metricsScrapeEnabled {
+		scrapeState = m.styles.Success.Render("enabled")
+	}
+	content.WriteString(fmt.Sprintf("%s %s\n", m.styles.StatLabel.Render("SCRAPE TARGETS:"), scrapeState))
+
+	if !m.metricsWatching {
+		content.WriteString(m.styles.Subtle.Render("Press 'm' to watch live throughput, latency and cloud-transfer metrics.\n"))
+	} else if m.metricsErr != nil {
+		content.WriteString(m.styles.Error.Render(fmt.Sprintf("Stream error: %v\n", m.metricsErr)))
+	} else if len(m.metricsSeries) == 0 {
+		content.WriteString(m.styles.Subtle.Render("Waiting for the first sample...\n"))
 	} else {
-		content.WriteString(subtleStyle.Render("No benchmark results yet.") + "\n\n")
-		content.WriteString("Press " + keywordStyle.Render("ENTER") + " to run a benchmark on sample data.\n")
-		content.WriteString(subtleStyle.Render("This will index data/sample and run standard queries.\n"))
-		content.WriteString("\n" + subtleStyle.Render("Press ESC to go back"))
+		names := make([]string, 0, len(m.metricsSeries))
+		for name := range m.metricsSeries {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			series := m.metricsSeries[name]
+			content.WriteString(fmt.Sprintf("  %-24s %s %.2f %s\n", name, renderSparkline(series), series[len(series)-1], m.metricsUnits[name]))
+		}
 	}
+	content.WriteString(m.styles.Subtle.Render("  [m] toggle watch   [t] toggle scrape targets\n"))
 
 	return lipgloss.NewStyle().Padding(1, 2).Render(content.String())
 }
 
+// viewCommunity renders the Community Benchmarks panel: the aggregate
+// median/p95 prismreports has on file for search latency, next to the
+// user's own last local run for comparison.
+func viewCommunity(m model) string {
+	var content strings.Builder
+	content.WriteString(m.styles.HeaderBox.Render("COMMUNITY BENCHMARKS") + "\n\n")
+	content.WriteString(fmt.Sprintf("%s %s\n\n", m.styles.StatLabel.Render("AGGREGATOR:"), m.telemetry.Endpoint))
+
+	if m.loadingCommunity {
+		content.WriteString(fmt.Sprintf("  %s Fetching community summary...\n", m.spinner.View()))
+	} else if m.communityErr != nil {
+		content.WriteString(m.styles.Error.Render(fmt.Sprintf("  Fetch failed: %v\n", m.communityErr)))
+	} else if m.communitySummary != nil && m.communitySummary.SampleCount > 0 {
+		s := m.communitySummary
+		content.WriteString(fmt.Sprintf("%s %s\n", m.styles.StatLabel.Render("METRIC:"), m.styles.Keyword.Render(s.Metric)))
+		if s.DeviceClass != "" {
+			content.WriteString(fmt.Sprintf("%s %s\n", m.styles.StatLabel.Render("DEVICE CLASS:"), s.DeviceClass))
+		}
+		content.WriteString(fmt.Sprintf("%s %d\n", m.styles.StatLabel.Render("SAMPLES:"), s.SampleCount))
+		content.WriteString(fmt.Sprintf("%s %.2f %s\n", m.styles.StatLabel.Render("MEDIAN:"), s.Median, s.Unit))
+		content.WriteString(fmt.Sprintf("%s %.2f %s\n\n", m.styles.StatLabel.Render("P95:"), s.P95, s.Unit))
+
+		if m.benchmarkReport != nil {
+			for _, metric := range m.benchmarkReport.SearchMetrics {
+				if metric.Name == s.Metric {
+					content.WriteString(fmt.Sprintf("%s %.2f %s\n", m.styles.StatLabel.Render("YOUR LAST RUN:"), metric.Value, metric.Unit))
+					break
+				}
+			}
+		}
+	} else {
+		content.WriteString(m.styles.Subtle.Render("  No community data yet for this metric.\n"))
+	}
+
+	content.WriteString("\n" + m.styles.Subtle.Render("Press ESC to go back"))
+	return lipgloss.NewStyle().Padding(1, 2).Render(content.String())
+}
+
 func viewDashboard(m model) string {
 	// Top: Banner
 	banner := RenderGradientBanner()
 
 	// Content Area
 	var content strings.Builder
-	content.WriteString(headerBoxStyle.Render("SYSTEM OVERVIEW") + "\n\n")
+	content.WriteString(m.styles.HeaderBox.Render("SYSTEM OVERVIEW") + "\n\n")
 
 	if m.loadingStats {
 		content.WriteString(m.spinner.View() + " Synchronizing with Neural Database...")
 	} else if m.stats != nil {
 		content.WriteString(fmt.Sprintf(
 			"The %s engine is currently monitoring %s frames with a total of %s multidimensional embeddings.\n\n",
-			keywordStyle.Render("Prism Neural Core"),
-			statValueStyle.Render(fmt.Sprintf("%d", m.stats.TotalFrames)),
-			statValueStyle.Render(fmt.Sprintf("%d", m.stats.TotalEmbeddings)),
+			m.styles.Keyword.Render("Prism Neural Core"),
+			m.styles.StatValue.Render(fmt.Sprintf("%d", m.stats.TotalFrames)),
+			m.styles.StatValue.Render(fmt.Sprintf("%d", m.stats.TotalEmbeddings)),
 		))
-		content.WriteString(fmt.Sprintf("Active Database: %s\n", keywordStyle.Render(m.stats.DbPath)))
-		content.WriteString(fmt.Sprintf("Last Ingestion Trace: %s\n", subtleStyle.Render(m.stats.LastIndexed)))
+		content.WriteString(fmt.Sprintf("Active Database: %s\n", m.styles.Keyword.Render(m.stats.DbPath)))
+		content.WriteString(fmt.Sprintf("Last Ingestion Trace: %s\n", m.styles.Subtle.Render(m.stats.LastIndexed)))
 	} else {
-		content.WriteString(subtleStyle.Render("No active intelligence trace detected. Please connect a database."))
+		content.WriteString(m.styles.Subtle.Render("No active intelligence trace detected. Please connect a database."))
 	}
 
 	// Menu
-	content.WriteString("\n\n" + headerBoxStyle.Render("COMMAND MODULES") + "\n")
+	content.WriteString("\n\n" + m.styles.HeaderBox.Render("COMMAND MODULES") + "\n")
 	for i, opt := range m.dashboardOptions {
 		cursor := "  "
-		style := subtleStyle
+		style := m.styles.Subtle
 		if i == m.dashboardCursor {
 			cursor = "❯ "
-			style = selectedItemStyle
+			style = m.styles.SelectedItem
 		}
 		content.WriteString(style.Render(cursor+opt) + "\n")
 	}
@@ -1300,79 +2646,40 @@ func viewDashboard(m model) string {
 func viewSearch(m model) string {
 	// Header Section
 	header := lipgloss.JoinVertical(lipgloss.Left,
-		headerBoxStyle.Render("NEURAL SEARCH INTERFACE")+" "+subtleStyle.Render("v1.0"),
+		m.styles.HeaderBox.Render("NEURAL SEARCH INTERFACE")+" "+m.styles.Subtle.Render("v1.0"),
 		m.searchInput.View(),
-		separatorStyle.Render(strings.Repeat("─", m.width-40)),
+		m.styles.Separator.Render(strings.Repeat("─", m.width-40)),
 	)
 
 	var content string
 	if m.searching {
-		content = "\n  " + m.spinner.View() + " Reconstructing visual topology..."
+		content = lipgloss.NewStyle().Height(m.height - 18).Render("\n  " + m.spinner.View() + " Reconstructing visual topology...")
 	} else if m.err != nil {
-		content = lipgloss.NewStyle().Padding(2).Render(
+		content = lipgloss.NewStyle().Height(m.height - 18).Render(lipgloss.NewStyle().Padding(2).Render(
 			lipgloss.JoinVertical(lipgloss.Left,
-				lipgloss.NewStyle().Foreground(errorColor).Bold(true).Render("!! CORE EXCEPTION !!"),
+				m.styles.Error.Render("!! CORE EXCEPTION !!"),
 				"",
 				m.err.Error(),
 			),
-		)
+		))
 	} else if len(m.results) == 0 {
-		content = "\n  " + subtleStyle.Render("Standing by for input. Models lazy-loaded on request.")
+		content = lipgloss.NewStyle().Height(m.height - 18).Render("\n  " + m.styles.Subtle.Render("Standing by for input. Models lazy-loaded on request."))
 	} else {
-		var rows []string
-
-		pageSize := 15
-		start := m.page * pageSize
-		end := start + pageSize
-		if end > len(m.results) {
-			end = len(m.results)
-		}
-
-		// Ensure start is valid (if results shrank drastically, though search resets page)
-		if start > len(m.results) {
-			start = len(m.results)
-		}
-
-		pageResults := m.results[start:end]
-
-		for i, res := range pageResults {
-			style := resultPathStyle
-			prefix := "  "
-			if i == m.cursor {
-				style = selectedResultStyle
-				prefix = "❯ "
-			}
-
-			path := res.Path
-			if len(path) > 40 {
-				path = "..." + path[len(path)-37:]
-			}
-
-			line := fmt.Sprintf("%-42s %s", path, resultScoreStyle.Render(fmt.Sprintf("%d%%", int(res.Confidence*100))))
-			rows = append(rows, style.Render(prefix+line))
-		}
-		content = lipgloss.JoinVertical(lipgloss.Left, rows...)
-
-		// Pagination Footer
-		totalPages := (len(m.results) + pageSize - 1) / pageSize
-		if totalPages > 1 {
-			footer := fmt.Sprintf("\n Page %d/%d (←/→)", m.page+1, totalPages)
-			content = lipgloss.JoinVertical(lipgloss.Left, content, subtleStyle.Render(footer))
-		}
+		content = m.resultsViewport.View()
 	}
 
 	return lipgloss.JoinVertical(lipgloss.Left,
 		header,
-		lipgloss.NewStyle().Height(m.height-18).Render(content),
+		content,
 	)
 }
 
 func viewIndex(m model) string {
 	header := lipgloss.JoinVertical(lipgloss.Left,
-		headerBoxStyle.Render("DATASET INGESTION PIPELINE"),
-		"Target Path: "+subtleStyle.Render("(Press 'o' to open folder picker)"),
+		m.styles.HeaderBox.Render("DATASET INGESTION PIPELINE"),
+		"Target Path: "+m.styles.Subtle.Render("(Press 'o' to open folder picker)"),
 		m.pathInput.View(),
-		separatorStyle.Render(strings.Repeat("─", m.width-40)),
+		m.styles.Separator.Render(strings.Repeat("─", m.width-40)),
 	)
 
 	var status string
@@ -1385,23 +2692,42 @@ func viewIndex(m model) string {
 			pct = float64(m.indexCurrent) / float64(m.indexTotal) * 100
 		}
 
-		// Format ETA
+		// Throughput, from the EWMA Update maintains over indexCurrent
+		// deltas, and the ETA it implies - preferred over the daemon's own
+		// EtaSeconds once we have enough samples to trust it.
+		throughputStr := "Calculating..."
 		etaStr := "Calculating..."
-		if m.indexETA > 0 {
-			mins := m.indexETA / 60
-			secs := m.indexETA % 60
+		if m.indexRate > 0 {
+			throughputStr = fmt.Sprintf("%.1f files/sec", m.indexRate)
+			if remaining := m.indexTotal - m.indexCurrent; remaining > 0 {
+				etaSecs := int32(float64(remaining) / m.indexRate)
+				mins, secs := etaSecs/60, etaSecs%60
+				if mins > 0 {
+					etaStr = fmt.Sprintf("%dm %ds remaining", mins, secs)
+				} else {
+					etaStr = fmt.Sprintf("%ds remaining", secs)
+				}
+			} else {
+				etaStr = "Complete!"
+			}
+		} else if m.indexETA > 0 {
+			mins, secs := m.indexETA/60, m.indexETA%60
 			if mins > 0 {
 				etaStr = fmt.Sprintf("%dm %ds remaining", mins, secs)
 			} else {
 				etaStr = fmt.Sprintf("%ds remaining", secs)
 			}
 		} else if m.indexCurrent > 0 && m.indexCurrent == m.indexTotal {
-			etaStr = "Complete!"
+			throughputStr, etaStr = "-", "Complete!"
 		}
 
 		// Build status lines
 		var statusLines []string
-		statusLines = append(statusLines, "\n"+keywordStyle.Render("INGESTION ACTIVE"))
+		activeLabel := "INGESTION ACTIVE"
+		if m.indexPaused {
+			activeLabel = "INGESTION PAUSED"
+		}
+		statusLines = append(statusLines, "\n"+m.styles.Keyword.Render(activeLabel))
 		statusLines = append(statusLines, m.progress.View())
 		statusLines = append(statusLines, "")
 
@@ -1410,15 +2736,72 @@ func viewIndex(m model) string {
 		if m.indexSkipped > 0 {
 			statsLine += fmt.Sprintf("  │  Skipped: %d", m.indexSkipped)
 		}
+		if m.indexResumed > 0 {
+			statsLine += fmt.Sprintf("  │  Resumed: %d", m.indexResumed)
+		}
+		if m.indexDeduped > 0 {
+			statsLine += fmt.Sprintf("  │  Deduped: %d", m.indexDeduped)
+		}
 		statusLines = append(statusLines, statsLine)
 
-		// ETA line
-		statusLines = append(statusLines, subtleStyle.Render("  ETA: "+etaStr))
+		// Throughput / ETA line
+		statusLines = append(statusLines, m.styles.Subtle.Render(fmt.Sprintf("  %s  │  ETA: %s", throughputStr, etaStr)))
 		statusLines = append(statusLines, "")
 
 		// Current operation
-		statusLines = append(statusLines, keywordStyle.Render("  STATUS"))
-		statusLines = append(statusLines, "  "+logTextStyle.Render(m.indexStatus))
+		statusLines = append(statusLines, m.styles.Keyword.Render("  STATUS"))
+		statusLines = append(statusLines, "  "+m.styles.LogText.Render(m.indexStatus))
+		statusLines = append(statusLines, "")
+
+		// Stacked multi-stage pipeline: one row per stage with its
+		// started/completed state and its own sliding files/s and MB/s,
+		// fed from the structured IndexEvents m.indexEvents collects.
+		statusLines = append(statusLines, m.styles.Keyword.Render("  PIPELINE"))
+		for _, stage := range indexStageNames {
+			icon := "○"
+			st := m.indexStages[stage]
+			if st != nil && st.completed {
+				icon = "✓"
+			} else if st != nil && st.started {
+				icon = "●"
+			}
+			filesPerSec, mbPerSec := m.stageThroughput(stage, indexThroughputWindow)
+			line := fmt.Sprintf("  %s %-8s %6.1f files/s  %7.2f MB/s", icon, stage, filesPerSec, mbPerSec)
+			if st != nil && st.completed {
+				line += fmt.Sprintf("  (%s)", st.duration)
+			}
+			statusLines = append(statusLines, m.styles.Subtle.Render(line))
+		}
+		statusLines = append(statusLines, "")
+
+		// p50/p95 embed latency, from the rolling indexEmbedLatencies
+		// ring - empty until the embed stage has processed anything.
+		if p50, p95 := m.embedLatencyPercentiles(); p50 > 0 || p95 > 0 {
+			statusLines = append(statusLines, m.styles.Subtle.Render(
+				fmt.Sprintf("  embed latency  p50: %.0fms  │  p95: %.0fms", p50, p95)))
+			statusLines = append(statusLines, "")
+		}
+
+		// Compact live log: the most recent structured events, newest
+		// last, with Ginkgo-style success/skip/retry denoters.
+		if len(m.indexEvents) > 0 {
+			statusLines = append(statusLines, m.styles.Keyword.Render("  RECENT EVENTS"))
+			start := len(m.indexEvents) - 8
+			if start < 0 {
+				start = 0
+			}
+			for _, e := range m.indexEvents[start:] {
+				statusLines = append(statusLines, "  "+m.styles.LogText.Render(indexEventLine(e)))
+			}
+			statusLines = append(statusLines, "")
+		}
+
+		// Controls
+		controlsLine := "  p: pause  │  x: cancel"
+		if m.indexPaused {
+			controlsLine = "  r: resume  │  x: cancel"
+		}
+		statusLines = append(statusLines, m.styles.Subtle.Render(controlsLine))
 
 		status = lipgloss.JoinVertical(lipgloss.Left, statusLines...)
 	} else {
@@ -1426,12 +2809,18 @@ func viewIndex(m model) string {
 		var lines []string
 		lines = append(lines, "")
 		if m.indexStatus != "" {
-			lines = append(lines, successStyle.Render("  "+m.indexStatus))
+			lines = append(lines, m.styles.Success.Render("  "+m.indexStatus))
 		} else {
-			lines = append(lines, subtleStyle.Render("  Enter a path or press 'o' to select a folder"))
+			lines = append(lines, m.styles.Subtle.Render("  Enter a path or press 'o' to select a folder"))
+		}
+		if m.indexLockHolder != "" {
+			lines = append(lines, m.styles.Error.Render("  Locked by "+m.indexLockHolder))
+			if m.devMode {
+				lines = append(lines, m.styles.Subtle.Render("  Press 'f' to force-steal the lock (devMode)"))
+			}
 		}
 		lines = append(lines, "")
-		lines = append(lines, subtleStyle.Render("  Press ENTER to start indexing"))
+		lines = append(lines, m.styles.Subtle.Render("  Press ENTER to start indexing"))
 		status = lipgloss.JoinVertical(lipgloss.Left, lines...)
 	}
 
@@ -1441,9 +2830,108 @@ func viewIndex(m model) string {
 	)
 }
 
+// indexEventLine renders one indexEvent for the live log, in the same
+// success/skip/retry denoter style Ginkgo's own spec reporter uses
+// (✓/⤼/↺), with stage lifecycle events shown plainly since they don't
+// carry a pass/fail outcome of their own.
+func indexEventLine(e indexEvent) string {
+	switch e.kind {
+	case "file_processed":
+		return fmt.Sprintf("✓ %s (%.0fms)", e.path, e.embedMs)
+	case "file_skipped":
+		return fmt.Sprintf("⤼ %s (%s)", e.path, e.reason)
+	case "file_retried":
+		return fmt.Sprintf("↺ %s (attempt %d)", e.path, e.attempt)
+	case "stage_started":
+		return fmt.Sprintf("▶ %s started", e.stage)
+	case "stage_completed":
+		return fmt.Sprintf("■ %s completed (%s)", e.stage, e.duration)
+	default:
+		return e.kind
+	}
+}
+
+// logLevelStyle maps a LogEntry's level to the color it's rendered in,
+// reusing the same palette errors/warnings/success use elsewhere.
+func logLevelStyle(s Styles, level string) lipgloss.Style {
+	switch strings.ToUpper(level) {
+	case "ERROR", "FATAL":
+		return s.Error
+	case "WARN", "WARNING":
+		return s.Warning
+	case "DEBUG":
+		return s.Subtle
+	default: // INFO and anything else
+		return s.ResultPath
+	}
+}
+
+// matchesLogFilter reports whether entry passes filter, a space-separated
+// list of terms. A term matching a known level name (case-insensitive)
+// restricts to that level; every other term must appear, case-insensitive,
+// somewhere in the entry's component or message.
+func matchesLogFilter(entry *pb.LogEntry, filter string) bool {
+	terms := strings.Fields(filter)
+	if len(terms) == 0 {
+		return true
+	}
+	haystack := strings.ToLower(entry.Component + " " + entry.Message)
+	for _, term := range terms {
+		term = strings.ToLower(term)
+		switch term {
+		case "debug", "info", "warn", "warning", "error", "fatal":
+			if !strings.EqualFold(entry.Level, term) {
+				return false
+			}
+		default:
+			if !strings.Contains(haystack, term) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func viewLogs(m model) string {
+	header := lipgloss.JoinVertical(lipgloss.Left,
+		m.styles.HeaderBox.Render("LIVE LOG STREAM")+" "+m.styles.Subtle.Render("v1.0"),
+		"Filter: "+m.logFilterInput.View()+m.styles.Subtle.Render("  (press '/' to edit)"),
+		m.styles.Separator.Render(strings.Repeat("─", m.width-40)),
+	)
+
+	var rows []string
+	for _, entry := range m.logs {
+		if !matchesLogFilter(entry, m.logFilterInput.Value()) {
+			continue
+		}
+		line := fmt.Sprintf("%s %s %s  %s",
+			m.styles.Subtle.Render(entry.Timestamp),
+			logLevelStyle(m.styles, entry.Level).Render(fmt.Sprintf("%-5s", entry.Level)),
+			m.styles.Keyword.Render(entry.Component),
+			entry.Message,
+		)
+		rows = append(rows, line)
+	}
+
+	if len(rows) == 0 {
+		rows = append(rows, "\n  "+m.styles.Subtle.Render("No log entries yet."))
+	}
+
+	// Tail: only the rows that fit the pane, newest at the bottom.
+	height := m.height - 18
+	if height > 0 && len(rows) > height {
+		rows = rows[len(rows)-height:]
+	}
+
+	return lipgloss.JoinVertical(lipgloss.Left,
+		header,
+		lipgloss.NewStyle().Height(height).Render(lipgloss.JoinVertical(lipgloss.Left, rows...)),
+	)
+}
+
 func viewConnectDB(m model) string {
 	content := lipgloss.JoinVertical(lipgloss.Left,
-		headerStyle.Render("CONNECT DATABASE"),
+		m.styles.Header.Render("CONNECT DATABASE"),
 		"",
 		"SQLite DB Path:",
 		m.dbInput.View(),
@@ -1451,46 +2939,49 @@ func viewConnectDB(m model) string {
 		m.spinner.View()+" "+m.dbStatus,
 	)
 
-	return lipgloss.Place(m.width, m.height/2, lipgloss.Center, lipgloss.Center, panelStyle.Render(content))
+	return lipgloss.Place(m.width, m.height/2, lipgloss.Center, lipgloss.Center, m.styles.Panel.Render(content))
 }
 
 func viewPro(m model) string {
 	var content strings.Builder
-	content.WriteString(headerBoxStyle.Render("🔮 SECRET FEATURES") + "\n\n")
+	content.WriteString(m.styles.HeaderBox.Render("🔮 SECRET FEATURES") + "\n\n")
 
 	if m.sysInfo != nil && m.sysInfo.IsPro {
-		content.WriteString(successStyle.Render("✔ Secret Features Unlocked!") + "\n\n")
+		content.WriteString(m.styles.Success.Render("✔ Secret Features Unlocked!") + "\n\n")
 		content.WriteString("You've discovered the hidden features:\n")
 		content.WriteString("• 🎨 Custom themes (coming soon)\n")
 		content.WriteString("• 🚀 Experimental neural modes\n")
 		content.WriteString("• 🔬 Advanced debugging tools\n")
 		content.WriteString("• ⚡ Early access to new features\n")
-		content.WriteString("\n" + subtleStyle.Render("Press ESC to return"))
+		content.WriteString("\n" + m.styles.Subtle.Render("Press ESC to return"))
 	} else {
 		content.WriteString("You found a hidden area...\n\n")
-		content.WriteString(keywordStyle.Render("ENTER SECRET CODE:") + "\n")
+		content.WriteString(m.styles.Keyword.Render("ENTER SECRET CODE:") + "\n")
 		content.WriteString(m.licenseInput.View() + "\n\n")
 
 		if m.activating {
 			content.WriteString(m.spinner.View() + " " + m.proStatus)
 		} else if m.proStatus != "" {
 			if strings.Contains(m.proStatus, "Activated") || strings.Contains(m.proStatus, "success") {
-				content.WriteString(successStyle.Render("✨ " + m.proStatus))
+				content.WriteString(m.styles.Success.Render("✨ " + m.proStatus))
 			} else {
-				content.WriteString(errorStyle.Render(m.proStatus))
+				content.WriteString(m.styles.Error.Render(m.proStatus))
 			}
 		} else {
-			content.WriteString(subtleStyle.Render("Press ENTER to unlock"))
+			content.WriteString(m.styles.Subtle.Render("Press ENTER to unlock"))
 		}
 
-		content.WriteString("\n\n" + subtleStyle.Render("Don't have a key? Visit prism.dev/upgrade"))
-		content.WriteString("\n" + subtleStyle.Render("Press ESC to return to dashboard"))
+		content.WriteString("\n\n" + m.styles.Subtle.Render("Don't have a key? Visit prism.dev/upgrade"))
+		content.WriteString("\n" + m.styles.Subtle.Render("Press ESC to return to dashboard"))
 	}
 
 	return lipgloss.NewStyle().Padding(1, 2).Render(content.String())
 }
 
-// addNotification adds a notification to the model (keeps last 10)
+// addNotification adds a notification to the model (keeps last 10) and, if
+// any event-bus sinks are configured, emits it as a structured notify.Event
+// too, so the same activity that shows up in the sidebar can also reach
+// Splunk/Datadog/Slack without polling the TUI.
 func (m *model) addNotification(ntype NotificationType, message string) {
 	n := Notification{
 		Type:      ntype,
@@ -1502,6 +2993,244 @@ func (m *model) addNotification(ntype NotificationType, message string) {
 	if len(m.notifications) > 10 {
 		m.notifications = m.notifications[len(m.notifications)-10:]
 	}
+
+	if m.eventBus != nil {
+		m.eventBus.Emit(notify.Event{
+			Kind:       "notification",
+			Severity:   notificationSeverity(ntype),
+			Timestamp:  n.Timestamp,
+			Attributes: map[string]string{"message": message},
+		})
+	}
+}
+
+// notificationSeverity maps the TUI's own NotificationType onto notify's
+// Severity; the two enums share the same ordering on purpose.
+func notificationSeverity(ntype NotificationType) notify.Severity {
+	return notify.Severity(ntype)
+}
+
+// releaseIndexLock calls indexLockRelease exactly once, if a lock is held.
+// Safe to call unconditionally from every path an index job can end on
+// (EOF, a fatal error, or a "cancel" ControlIndex round trip).
+func (m *model) releaseIndexLock() {
+	if m.indexLockRelease != nil {
+		m.indexLockRelease()
+		m.indexLockRelease = nil
+	}
+}
+
+// pushIndexEvent appends e to m.indexEvents (trimming to
+// indexEventRingSize), updates the named stage's started/completed state,
+// and folds a file_processed event's embed_ms into m.indexEmbedLatencies.
+func (m *model) pushIndexEvent(e indexEvent) {
+	m.indexEvents = append(m.indexEvents, e)
+	if over := len(m.indexEvents) - indexEventRingSize; over > 0 {
+		m.indexEvents = m.indexEvents[over:]
+	}
+
+	switch e.kind {
+	case "stage_started":
+		st := m.indexStages[e.stage]
+		if st == nil {
+			st = &indexStageState{}
+			m.indexStages[e.stage] = st
+		}
+		st.started = true
+	case "stage_completed":
+		st := m.indexStages[e.stage]
+		if st == nil {
+			st = &indexStageState{}
+			m.indexStages[e.stage] = st
+		}
+		st.started = true
+		st.completed = true
+		st.duration = e.duration
+	case "file_processed":
+		if e.embedMs > 0 {
+			m.indexEmbedLatencies = append(m.indexEmbedLatencies, e.embedMs)
+			if over := len(m.indexEmbedLatencies) - indexEventRingSize; over > 0 {
+				m.indexEmbedLatencies = m.indexEmbedLatencies[over:]
+			}
+		}
+	}
+}
+
+// stageThroughput computes stage's sliding files/s and MB/s from
+// file_processed events in m.indexEvents within the last window.
+func (m model) stageThroughput(stage string, window time.Duration) (filesPerSec, mbPerSec float64) {
+	cutoff := time.Now().Add(-window)
+	var files, totalBytes int64
+	var earliest time.Time
+	for _, e := range m.indexEvents {
+		if e.kind != "file_processed" || e.stage != stage || e.at.Before(cutoff) {
+			continue
+		}
+		files++
+		totalBytes += e.bytes
+		if earliest.IsZero() || e.at.Before(earliest) {
+			earliest = e.at
+		}
+	}
+	if files == 0 {
+		return 0, 0
+	}
+	elapsed := time.Since(earliest).Seconds()
+	if elapsed < 1 {
+		elapsed = 1
+	}
+	return float64(files) / elapsed, float64(totalBytes) / (1024 * 1024) / elapsed
+}
+
+// embedLatencyPercentiles returns the p50/p95 of m.indexEmbedLatencies as
+// sorted[floor(p*(n-1))] on a sorted copy - the same index rule
+// cmd/prismreports' store uses for p95, though its median is sorted[n/2].
+func (m model) embedLatencyPercentiles() (p50, p95 float64) {
+	if len(m.indexEmbedLatencies) == 0 {
+		return 0, 0
+	}
+	sorted := append([]float64(nil), m.indexEmbedLatencies...)
+	sort.Float64s(sorted)
+	rank := func(frac float64) float64 {
+		i := int(frac * float64(len(sorted)-1))
+		return sorted[i]
+	}
+	return rank(0.50), rank(0.95)
+}
+
+// cloudInputs returns the textinputs relevant to the current provider and
+// cloudAuthMode, in display/focus order. Instance-profile and
+// managed-identity modes need no credentials at all, so they return nil.
+func (m *model) cloudInputs() []*textinput.Model {
+	if m.cloudProvider == 0 { // AWS
+		switch m.cloudAuthMode {
+		case 1: // AssumeRole
+			return []*textinput.Model{&m.awsRoleArn, &m.awsExternalID, &m.awsRegion}
+		case 2: // Instance profile (IMDSv2)
+			return nil
+		default: // Access keys
+			return []*textinput.Model{&m.awsAccessKey, &m.awsSecretKey, &m.awsRegion}
+		}
+	}
+	switch m.cloudAuthMode { // Azure
+	case 1: // SAS token
+		return []*textinput.Model{&m.azureSasToken}
+	case 2: // Managed identity
+		return nil
+	default: // Connection string
+		return []*textinput.Model{&m.azureConnStr}
+	}
+}
+
+// focusCloudInput blurs every cloud-credential input, then focuses the one
+// at m.cloudFocusIndex within the current provider/authMode's cloudInputs(),
+// the same focus-cycle pattern focusNotifyInput uses.
+func (m *model) focusCloudInput() {
+	for _, in := range []*textinput.Model{&m.awsAccessKey, &m.awsSecretKey, &m.awsRegion, &m.awsRoleArn, &m.awsExternalID, &m.azureConnStr, &m.azureSasToken} {
+		in.Blur()
+	}
+	inputs := m.cloudInputs()
+	if len(inputs) == 0 {
+		return
+	}
+	if m.cloudFocusIndex < 0 {
+		m.cloudFocusIndex = len(inputs) - 1
+	}
+	if m.cloudFocusIndex >= len(inputs) {
+		m.cloudFocusIndex = 0
+	}
+	inputs[m.cloudFocusIndex].Focus()
+}
+
+// anyCloudInputFocused reports whether a cloud-credential field currently
+// has focus, so the Save/Test key bindings can tell a field keystroke from
+// an actual Save/Test shortcut the way they already do for AWS/Azure today.
+func (m *model) anyCloudInputFocused() bool {
+	for _, in := range m.cloudInputs() {
+		if in.Focused() {
+			return true
+		}
+	}
+	return false
+}
+
+// focusNotifyInput applies m.notifyFocusIndex to the three Notification
+// Sinks text fields, the same focus-cycle pattern cloudFocusIndex uses.
+func (m *model) focusNotifyInput() {
+	m.notifyWebhookURL.Blur()
+	m.notifyWebhookToken.Blur()
+	m.notifyUnixSocket.Blur()
+	switch m.notifyFocusIndex {
+	case 0:
+		m.notifyWebhookURL.Focus()
+	case 1:
+		m.notifyWebhookToken.Focus()
+	case 2:
+		m.notifyUnixSocket.Focus()
+	}
+}
+
+// ensureCursorVisible scrolls the results viewport so the row at m.cursor
+// is within its visible window, the way moving the selection used to flip
+// the old fixed-size page.
+func (m *model) ensureCursorVisible() {
+	if m.resultsViewport.Height <= 0 {
+		return
+	}
+	if m.cursor < m.resultsViewport.YOffset {
+		m.resultsViewport.SetYOffset(m.cursor)
+	} else if m.cursor >= m.resultsViewport.YOffset+m.resultsViewport.Height {
+		m.resultsViewport.SetYOffset(m.cursor - m.resultsViewport.Height + 1)
+	}
+}
+
+// resultsRows renders one line per search result, highlighting m.cursor's
+// row with the banner's shimmer, plus a trailing "loading more"/"end of N
+// result(s)" line - the content syncResultsViewport stores on
+// m.resultsViewport.
+func (m model) resultsRows() []string {
+	rows := make([]string, 0, len(m.results)+1)
+
+	for i, res := range m.results {
+		style := m.styles.ResultPath
+		prefix := "  "
+		if i == m.cursor {
+			style = m.styles.ResultHit
+			prefix = "❯ "
+		}
+
+		path := res.Path
+		if len(path) > 40 {
+			path = "..." + path[len(path)-37:]
+		}
+
+		line := fmt.Sprintf("%-42s %s", path, m.styles.ResultScore.Render(fmt.Sprintf("%d%%", int(res.Confidence*100))))
+		if i == m.cursor {
+			// Reuse the banner's shimmer on the active row instead of a
+			// flat accent color, via the same GradientColor machinery.
+			rows = append(rows, RenderShimmerText(prefix+line))
+		} else {
+			rows = append(rows, style.Render(prefix+line))
+		}
+	}
+
+	if len(m.results) > 0 {
+		if m.loadingMore {
+			rows = append(rows, "\n  "+m.spinner.View()+" Loading more results...")
+		} else if !m.searchHasMore {
+			rows = append(rows, "\n  "+m.styles.Subtle.Render(fmt.Sprintf("— end of %d result(s) —", len(m.results))))
+		}
+	}
+
+	return rows
+}
+
+// syncResultsViewport re-renders resultsRows onto m.resultsViewport itself,
+// rather than a throwaway copy made at render time, so its content's line
+// count stays accurate and pgup/pgdown/home/end/AtBottom can scroll past
+// the first screenful.
+func (m *model) syncResultsViewport() {
+	m.resultsViewport.SetContent(lipgloss.JoinVertical(lipgloss.Left, m.resultsRows()...))
 }
 
 // formatRelativeTime returns a human-readable relative time string
@@ -1527,43 +3256,85 @@ func max(a, b int) int {
 func viewCloudConfig(m model) string {
 	var content strings.Builder
 
-	content.WriteString(headerBoxStyle.Render("CONFIGURE CLOUD CREDENTIALS") + "\n\n")
+	content.WriteString(m.styles.HeaderBox.Render("CONFIGURE CLOUD CREDENTIALS") + "\n\n")
 
 	// Tabs
 	awsTab := " AWS S3 "
 	azureTab := " Azure Blob "
 
 	if m.cloudProvider == 0 {
-		awsTab = activeTabStyle.Render(awsTab)
-		azureTab = tabStyle.Render(azureTab)
+		awsTab = m.styles.ActiveTab.Render(awsTab)
+		azureTab = m.styles.Tab.Render(azureTab)
 	} else {
-		awsTab = tabStyle.Render(awsTab)
-		azureTab = activeTabStyle.Render(azureTab)
+		awsTab = m.styles.Tab.Render(awsTab)
+		azureTab = m.styles.ActiveTab.Render(azureTab)
 	}
 
 	content.WriteString(awsTab + "  " + azureTab + "\n\n")
-	content.WriteString(subtleStyle.Render("Press TAB to switch providers.") + "\n\n")
+	content.WriteString(m.styles.Subtle.Render("Press TAB to switch providers.") + "\n\n")
 
+	var authModes []string
 	if m.cloudProvider == 0 {
-		content.WriteString("Access Key ID:\n")
-		content.WriteString(m.awsAccessKey.View() + "\n\n")
-
-		content.WriteString("Secret Access Key:\n")
-		content.WriteString(m.awsSecretKey.View() + "\n\n")
+		authModes = []string{"Access Keys", "AssumeRole", "Instance Profile (IMDSv2)"}
+	} else {
+		authModes = []string{"Connection String", "SAS Token", "Managed Identity"}
+	}
+	var modeLine strings.Builder
+	for i, name := range authModes {
+		label := " " + name + " "
+		if i == m.cloudAuthMode {
+			modeLine.WriteString(m.styles.ActiveTab.Render(label))
+		} else {
+			modeLine.WriteString(m.styles.Tab.Render(label))
+		}
+		modeLine.WriteString(" ")
+	}
+	content.WriteString(modeLine.String() + "\n")
+	content.WriteString(m.styles.Subtle.Render("Press 'a' to cycle auth mode.") + "\n\n")
 
-		content.WriteString("Region:\n")
-		content.WriteString(m.awsRegion.View() + "\n\n")
+	if m.cloudProvider == 0 {
+		switch m.cloudAuthMode {
+		case 1: // AssumeRole - base credentials come from the environment
+			content.WriteString("Role ARN:\n")
+			content.WriteString(m.awsRoleArn.View() + "\n\n")
+
+			content.WriteString("External ID:\n")
+			content.WriteString(m.awsExternalID.View() + "\n\n")
+
+			content.WriteString("Region:\n")
+			content.WriteString(m.awsRegion.View() + "\n\n")
+		case 2: // Instance profile - no credential fields at all
+			content.WriteString(m.styles.Subtle.Render("Credentials auto-detected from the instance's IMDSv2 role; no fields needed.") + "\n\n")
+		default:
+			content.WriteString("Access Key ID:\n")
+			content.WriteString(m.awsAccessKey.View() + "\n\n")
+
+			content.WriteString("Secret Access Key:\n")
+			content.WriteString(m.awsSecretKey.View() + "\n\n")
+
+			content.WriteString("Region:\n")
+			content.WriteString(m.awsRegion.View() + "\n\n")
+		}
 	} else {
-		content.WriteString("Connection String:\n")
-		content.WriteString(m.azureConnStr.View() + "\n\n")
+		switch m.cloudAuthMode {
+		case 1:
+			content.WriteString("SAS Token:\n")
+			content.WriteString(m.azureSasToken.View() + "\n\n")
+		case 2:
+			content.WriteString(m.styles.Subtle.Render("Credentials auto-detected via Azure Managed Identity; no fields needed.") + "\n\n")
+		default:
+			content.WriteString("Connection String:\n")
+			content.WriteString(m.azureConnStr.View() + "\n\n")
+		}
 	}
 
 	content.WriteString("\n")
-	content.WriteString("  " + keywordStyle.Render("[↑/↓]") + " Navigate fields\n")
-	content.WriteString("  " + keywordStyle.Render("[ENTER]") + " Save Credentials\n")
-	content.WriteString("  " + keywordStyle.Render("[T]") + " Test Connection\n")
-	content.WriteString("  " + keywordStyle.Render("[TAB]") + " Switch Provider\n")
-	content.WriteString("  " + keywordStyle.Render("[ESC]") + " Back\n")
+	content.WriteString("  " + m.styles.Keyword.Render("[↑/↓]") + " Navigate fields\n")
+	content.WriteString("  " + m.styles.Keyword.Render("[A]") + " Cycle Auth Mode\n")
+	content.WriteString("  " + m.styles.Keyword.Render("[ENTER]") + " Save Credentials\n")
+	content.WriteString("  " + m.styles.Keyword.Render("[T]") + " Test Connection\n")
+	content.WriteString("  " + m.styles.Keyword.Render("[TAB]") + " Switch Provider\n")
+	content.WriteString("  " + m.styles.Keyword.Render("[ESC]") + " Back\n")
 
 	if m.cloudStatus != "" {
 		content.WriteString("\nStatus: " + m.cloudStatus + "\n")
@@ -1572,22 +3343,176 @@ func viewCloudConfig(m model) string {
 	return lipgloss.NewStyle().Padding(1, 2).Render(content.String())
 }
 
+// viewNotifyConfig renders the Settings > Notification Sinks screen: a
+// stdout toggle plus the webhook/unix-socket destinations for the
+// notify.Bus, following the same field-by-field layout as viewCloudConfig.
+func viewNotifyConfig(m model) string {
+	var content strings.Builder
+	content.WriteString(m.styles.HeaderBox.Render("NOTIFICATION SINKS") + "\n\n")
+	content.WriteString(m.styles.Subtle.Render("Pipe indexing/search/benchmark activity to external systems.") + "\n\n")
+
+	stdoutState := "OFF"
+	if m.notifyStdoutEnabled {
+		stdoutState = m.styles.Success.Render("ON")
+	}
+	content.WriteString(fmt.Sprintf("%s %s\n\n", m.styles.StatLabel.Render("STDOUT JSON LINES:"), stdoutState))
+
+	content.WriteString("Webhook URL (authToken sent as Bearer):\n")
+	content.WriteString(m.notifyWebhookURL.View() + "\n\n")
+
+	content.WriteString("Webhook Auth Token:\n")
+	content.WriteString(m.notifyWebhookToken.View() + "\n\n")
+
+	content.WriteString("Unix Socket Path (tail for a live event stream):\n")
+	content.WriteString(m.notifyUnixSocket.View() + "\n\n")
+
+	content.WriteString("  " + m.styles.Keyword.Render("[↑/↓]") + " Navigate fields\n")
+	content.WriteString("  " + m.styles.Keyword.Render("[O]") + " Toggle stdout sink\n")
+	content.WriteString("  " + m.styles.Keyword.Render("[ENTER]") + " Save\n")
+	content.WriteString("  " + m.styles.Keyword.Render("[ESC]") + " Back\n")
+
+	if m.notifyStatus != "" {
+		content.WriteString("\nStatus: " + m.notifyStatus + "\n")
+	}
+
+	return lipgloss.NewStyle().Padding(1, 2).Render(content.String())
+}
+
 type cloudSaveMsg struct {
 	success bool
 	message string
 }
 
-func saveCloudCredentialsCmd(client pb.PrismServiceClient, provider, awsKey, awsSecret, awsRegion, azConn string) tea.Cmd {
+// cloudTestMsg carries the result of "Test Connection" - a real HeadBucket
+// (AWS) or ListContainers (Azure) probe against the daemon's cloud client,
+// so latency/region only come back on success.
+type cloudTestMsg struct {
+	success   bool
+	message   string
+	latencyMs int64
+	region    string
+}
+
+// cloudAuthModeString renders m.cloudAuthMode as the string SaveCloudCredentialsRequest.AuthMode
+// expects, which differs by provider: instance-profile/managed-identity
+// skip credentials entirely, so the daemon needs the mode name to know
+// which SDK credential chain to use instead of inferring it from which
+// fields are empty.
+func (m model) cloudAuthModeString() string {
+	if m.cloudProvider == 0 { // AWS
+		switch m.cloudAuthMode {
+		case 1:
+			return "assume_role"
+		case 2:
+			return "instance_profile"
+		default:
+			return "access_key"
+		}
+	}
+	switch m.cloudAuthMode { // Azure
+	case 1:
+		return "sas_token"
+	case 2:
+		return "managed_identity"
+	default:
+		return "connection_string"
+	}
+}
+
+// saveCloudCredentialsCmd builds the SaveCloudCredentials call for whichever
+// provider/authMode is currently selected, so the "enter" and "s" key
+// bindings don't each have to assemble the same ten-odd fields by hand.
+func (m model) saveCloudCredentialsCmd() tea.Cmd {
+	provider := "aws"
+	if m.cloudProvider == 1 {
+		provider = "azure"
+	}
+	return saveCloudCredentialsCmd(m.client, provider, m.cloudAuthModeString(),
+		m.awsAccessKey.Value(), m.awsSecretKey.Value(), m.awsRegion.Value(), m.awsRoleArn.Value(), m.awsExternalID.Value(),
+		m.azureConnStr.Value(), m.azureSasToken.Value())
+}
+
+type notifyConfigSavedMsg struct {
+	success bool
+	message string
+}
+
+// rebuiltEventBus builds the notify.Bus the model should switch to after a
+// successful SaveNotificationConfig: one sink per enabled destination. A
+// socket or webhook that fails to construct is dropped with a stderr
+// warning rather than failing the whole save, the same tolerance
+// loadedPlugins shows a single bad plugin.
+func rebuiltEventBus(stdoutEnabled bool, unixSocketPath, webhookURL, webhookToken string) *notify.Bus {
+	var sinks []notify.Sink
+	if stdoutEnabled {
+		sinks = append(sinks, notify.StdoutSink{})
+	}
+	if unixSocketPath != "" {
+		sink, err := notify.NewUnixSocketSink(unixSocketPath)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "prism: %v; unix-socket sink disabled\n", err)
+		} else {
+			sinks = append(sinks, sink)
+		}
+	}
+	if webhookURL != "" {
+		sinks = append(sinks, notify.NewWebhookSink(webhookURL, webhookToken))
+	}
+	return notify.NewBus(sinks...)
+}
+
+// viewProfiles renders the Ctrl+P connection picker: every profile from
+// profiles.toml (or just "local" if none is configured), with the active
+// one marked and the cursor's row shimmered the same way Search highlights
+// its selected result.
+func viewProfiles(m model) string {
+	var content strings.Builder
+	content.WriteString(m.styles.HeaderBox.Render("CONNECTION PROFILES") + "\n\n")
+	content.WriteString(m.styles.Subtle.Render("↑/↓: select  •  ENTER: connect  •  ESC: cancel") + "\n\n")
+
+	for i, p := range m.profiles {
+		prefix := "  "
+		marker := " "
+		if i == m.activeProfile {
+			marker = "●"
+		}
+		line := fmt.Sprintf("%s %-16s %s", marker, p.Name, m.styles.Subtle.Render(p.Address))
+		if p.TLS {
+			line += m.styles.Subtle.Render("  [tls]")
+		}
+
+		if i == m.profileCursor {
+			prefix = "❯ "
+			content.WriteString(RenderShimmerText(prefix+line) + "\n")
+		} else {
+			content.WriteString(m.styles.ResultPath.Render(prefix+line) + "\n")
+		}
+	}
+
+	return lipgloss.NewStyle().Padding(1, 2).Render(content.String())
+}
+
+// saveCloudCredentialsCmd sends whatever the selected authMode needs to the
+// daemon's SaveCloudCredentials RPC. The daemon side of this - actually
+// wiring aws-sdk-go-v2/Azure SDK clients against AuthMode, calling
+// sts.AssumeRole or loading the Azure SAS/managed-identity credential,
+// IMDSv2 instance-profile detection - lives outside this repo; the TUI only
+// collects and forwards the fields a real implementation would need.
+func saveCloudCredentialsCmd(client pb.PrismServiceClient, provider, authMode, awsKey, awsSecret, awsRegion, roleArn, externalID, azConn, sasToken string) tea.Cmd {
 	return func() tea.Msg {
 		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 		defer cancel()
 
 		resp, err := client.SaveCloudCredentials(ctx, &pb.SaveCloudCredentialsRequest{
 			Provider:              provider,
+			AuthMode:              authMode,
 			AwsAccessKey:          awsKey,
 			AwsSecretKey:          awsSecret,
 			AwsRegion:             awsRegion,
+			RoleArn:               roleArn,
+			ExternalId:            externalID,
 			AzureConnectionString: azConn,
+			SasToken:              sasToken,
 		})
 
 		if err != nil {
@@ -1600,6 +3525,10 @@ func saveCloudCredentialsCmd(client pb.PrismServiceClient, provider, awsKey, aws
 	}
 }
 
+// validateCloudCredentialsCmd runs "Test Connection": a real HeadBucket (AWS)
+// or ListContainers (Azure) probe against whatever credentials/role were
+// last saved, daemon-side. LatencyMs/Region only come back meaningful on
+// success.
 func validateCloudCredentialsCmd(client pb.PrismServiceClient, provider string) tea.Cmd {
 	return func() tea.Msg {
 		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
@@ -1610,16 +3539,51 @@ func validateCloudCredentialsCmd(client pb.PrismServiceClient, provider string)
 		})
 
 		if err != nil {
-			return cloudSaveMsg{success: false, message: err.Error()}
+			return cloudTestMsg{success: false, message: err.Error()}
 		}
 		if resp == nil {
-			return cloudSaveMsg{success: false, message: "Empty response from server"}
+			return cloudTestMsg{success: false, message: "Empty response from server"}
 		}
-		return cloudSaveMsg{success: resp.Success, message: resp.Message}
+		return cloudTestMsg{success: resp.Success, message: resp.Message, latencyMs: resp.LatencyMs, region: resp.Region}
+	}
+}
+
+func saveNotificationConfigCmd(client pb.PrismServiceClient, stdoutEnabled bool, webhookURL, webhookToken, unixSocketPath string) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		resp, err := client.SaveNotificationConfig(ctx, &pb.SaveNotificationConfigRequest{
+			StdoutEnabled:  stdoutEnabled,
+			WebhookUrl:     webhookURL,
+			WebhookToken:   webhookToken,
+			UnixSocketPath: unixSocketPath,
+		})
+
+		if err != nil {
+			return notifyConfigSavedMsg{success: false, message: err.Error()}
+		}
+		if resp == nil {
+			return notifyConfigSavedMsg{success: false, message: "Empty response from server"}
+		}
+		return notifyConfigSavedMsg{success: resp.Success, message: resp.Message}
 	}
 }
 
 func main() {
+	activeTheme = loadUserTheme(os.Args)
+	activeGraphics = detectGraphicsProtocol(hasFlag(os.Args, "--no-graphics"))
+
+	if serveFromArgs(os.Args) {
+		return
+	}
+	if runReportFromArgs(os.Args) {
+		return
+	}
+	if describeFromArgs(os.Args) {
+		return
+	}
+
 	p := tea.NewProgram(initialModel(), tea.WithAltScreen())
 	if _, err := p.Run(); err != nil {
 		log.Fatal(err)
@@ -0,0 +1,126 @@
+package main
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/BurntSushi/toml"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// Profile is one named backend a user can point the TUI at, loaded from
+// ~/.config/prism/profiles.toml. The built-in localDevProfile is what the
+// TUI starts with if no profiles file exists.
+type Profile struct {
+	Name     string `toml:"name"`
+	Address  string `toml:"address"`
+	DBPath   string `toml:"db_path"`
+	APIToken string `toml:"api_token"`
+
+	TLS                bool   `toml:"tls"`
+	CertFile           string `toml:"cert_file"`
+	KeyFile            string `toml:"key_file"`
+	CAFile             string `toml:"ca_file"`
+	InsecureSkipVerify bool   `toml:"insecure_skip_verify"`
+}
+
+// profilesFile is the on-disk shape of profiles.toml: a list of [[profiles]]
+// tables, one per Profile.
+type profilesFile struct {
+	Profiles []Profile `toml:"profiles"`
+}
+
+// localDevProfile is the built-in fallback profile so the TUI still works
+// with no profiles.toml present.
+func localDevProfile() Profile {
+	return Profile{Name: "local", Address: "localhost:50051"}
+}
+
+// defaultProfilesPath returns ~/.config/prism/profiles.toml (honoring
+// XDG_CONFIG_HOME via os.UserConfigDir, same as firstExistingThemeConfig).
+func defaultProfilesPath() string {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "prism", "profiles.toml")
+}
+
+// loadProfiles reads profiles.toml at path. A missing file is not an error -
+// it just means the user hasn't set any profiles up yet, so the caller
+// falls back to localDevProfile.
+func loadProfiles(path string) ([]Profile, error) {
+	if path == "" {
+		return nil, nil
+	}
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		return nil, nil
+	}
+	var pf profilesFile
+	if _, err := toml.DecodeFile(path, &pf); err != nil {
+		return nil, fmt.Errorf("loading profiles: %w", err)
+	}
+	return pf.Profiles, nil
+}
+
+// dialOptionsForProfile builds the grpc.DialOptions a Profile needs:
+// mTLS via credentials.NewTLS when TLS is set, insecure.NewCredentials
+// otherwise, plus a PerRPCCredentials that attaches APIToken as a bearer
+// token when one is configured.
+func dialOptionsForProfile(p Profile) ([]grpc.DialOption, error) {
+	var opts []grpc.DialOption
+
+	if p.TLS {
+		tlsConfig := &tls.Config{InsecureSkipVerify: p.InsecureSkipVerify}
+
+		if p.CAFile != "" {
+			pem, err := os.ReadFile(p.CAFile)
+			if err != nil {
+				return nil, fmt.Errorf("reading ca_file: %w", err)
+			}
+			pool := x509.NewCertPool()
+			if !pool.AppendCertsFromPEM(pem) {
+				return nil, fmt.Errorf("ca_file %q contains no usable certificates", p.CAFile)
+			}
+			tlsConfig.RootCAs = pool
+		}
+		if p.CertFile != "" && p.KeyFile != "" {
+			cert, err := tls.LoadX509KeyPair(p.CertFile, p.KeyFile)
+			if err != nil {
+				return nil, fmt.Errorf("loading client cert/key: %w", err)
+			}
+			tlsConfig.Certificates = []tls.Certificate{cert}
+		}
+
+		opts = append(opts, grpc.WithTransportCredentials(credentials.NewTLS(tlsConfig)))
+	} else {
+		opts = append(opts, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	}
+
+	if p.APIToken != "" {
+		opts = append(opts, grpc.WithPerRPCCredentials(bearerToken{token: p.APIToken, insecure: !p.TLS}))
+	}
+
+	return opts, nil
+}
+
+// bearerToken implements credentials.PerRPCCredentials, attaching a
+// profile's APIToken to every outgoing RPC as an Authorization header.
+type bearerToken struct {
+	token    string
+	insecure bool
+}
+
+func (b bearerToken) GetRequestMetadata(ctx context.Context, uri ...string) (map[string]string, error) {
+	return map[string]string{"authorization": "Bearer " + b.token}, nil
+}
+
+func (b bearerToken) RequireTransportSecurity() bool {
+	return !b.insecure
+}
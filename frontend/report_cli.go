@@ -0,0 +1,109 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+
+	pb "github.com/sjanney/prism/proto"
+)
+
+// reportFlagFromArgs scans args for --report=fmt:path, e.g.
+// --report=junit:benchmark.xml, splitting it into the reporterForFormat ID
+// and the destination path.
+func reportFlagFromArgs(args []string) (format, path string, ok bool) {
+	for _, a := range args[1:] {
+		rest, found := strings.CutPrefix(a, "--report=")
+		if !found {
+			continue
+		}
+		format, path, ok = strings.Cut(rest, ":")
+		return
+	}
+	return "", "", false
+}
+
+// runReportFromArgs handles `prism --report=fmt:path` before falling
+// through to the normal local TUI entry point in main(), the same
+// early-intercept shape serveFromArgs uses for `prism serve`. It lets CI
+// run a benchmark and gate on the result without a terminal at all.
+func runReportFromArgs(args []string) (handled bool) {
+	format, path, ok := reportFlagFromArgs(args)
+	if !ok {
+		return false
+	}
+
+	if err := runBenchmarkReport(format, path); err != nil {
+		fmt.Fprintln(os.Stderr, "prism:", err)
+		os.Exit(1)
+	}
+	return true
+}
+
+// runBenchmarkReport dials the first configured profile, runs a benchmark
+// against data/sample to completion while printing each progress message to
+// stdout, and writes the result through both a stdout StreamingReporter (so
+// CI logs show the metrics) and the requested export format's Reporter.
+func runBenchmarkReport(format, path string) error {
+	profile := loadedProfiles()[0]
+	opts, err := dialOptionsForProfile(profile)
+	if err != nil {
+		return fmt.Errorf("building dial options: %w", err)
+	}
+
+	dialCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	conn, err := grpc.DialContext(dialCtx, profile.Address, append(opts, grpc.WithBlock())...)
+	if err != nil {
+		return fmt.Errorf("dialing %s: %w", profile.Address, err)
+	}
+	defer conn.Close()
+
+	client := pb.NewPrismServiceClient(conn)
+
+	stream, err := client.RunBenchmark(context.Background(), &pb.RunBenchmarkRequest{SamplePath: "data/sample"})
+	if err != nil {
+		return fmt.Errorf("starting benchmark: %w", err)
+	}
+	for {
+		progress, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("streaming benchmark progress: %w", err)
+		}
+		fmt.Printf("[%s] %s\n", progress.Phase, progress.Message)
+	}
+
+	report, err := client.GetBenchmarkReport(context.Background(), &pb.GetBenchmarkReportRequest{})
+	if err != nil {
+		return fmt.Errorf("fetching benchmark report: %w", err)
+	}
+
+	if err := driveReporter(&StreamingReporter{Writer: os.Stdout, Verbosity: VerbosityNormal}, report); err != nil {
+		return err
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("creating %s: %w", path, err)
+	}
+	defer f.Close()
+
+	reporter, err := reporterForFormat(format, f)
+	if err != nil {
+		return err
+	}
+	if err := driveReporter(reporter, report); err != nil {
+		return err
+	}
+
+	fmt.Printf("prism: wrote %s report to %s\n", format, path)
+	return nil
+}
@@ -0,0 +1,262 @@
+package main
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	pb "github.com/sjanney/prism/proto"
+)
+
+// Reporter receives a benchmark run's lifecycle the way Ginkgo's own
+// Reporter interface does: Begin once the run starts, MetricEmitted once
+// per metric as the final report is walked, End once it's done. Every
+// concrete Reporter below gets driven through exactly this sequence,
+// whether it's streaming to the `--report` CLI's stdout, or producing one
+// of the export formats viewBenchmark's [e] picker offers.
+type Reporter interface {
+	Begin(report *pb.BenchmarkReport) error
+	MetricEmitted(metric *pb.Metric) error
+	End(report *pb.BenchmarkReport) error
+}
+
+// reportMetrics flattens a report's three metric categories in the fixed
+// order every Reporter below assumes: indexing, then search, then system.
+//
+// report.IndexingMetrics is itself computed server-side from RunBenchmark's
+// indexing phase; ideally that aggregation would consume the same typed
+// IndexEvents the Index RPC now streams (see indexEvent in main.go) instead
+// of deriving metrics post-hoc, but that aggregation lives in the daemon,
+// which isn't part of this tree - nothing to change here.
+func reportMetrics(report *pb.BenchmarkReport) []*pb.Metric {
+	var all []*pb.Metric
+	all = append(all, report.IndexingMetrics...)
+	all = append(all, report.SearchMetrics...)
+	all = append(all, report.SystemMetrics...)
+	return all
+}
+
+// driveReporter runs r through the full Begin/MetricEmitted.../End
+// sequence for report, stopping at the first error.
+func driveReporter(r Reporter, report *pb.BenchmarkReport) error {
+	if err := r.Begin(report); err != nil {
+		return fmt.Errorf("reporter Begin: %w", err)
+	}
+	for _, m := range reportMetrics(report) {
+		if err := r.MetricEmitted(m); err != nil {
+			return fmt.Errorf("reporter MetricEmitted(%s): %w", m.Name, err)
+		}
+	}
+	if err := r.End(report); err != nil {
+		return fmt.Errorf("reporter End: %w", err)
+	}
+	return nil
+}
+
+// Verbosity controls how much detail StreamingReporter prints per metric,
+// the same three-level scale Ginkgo's default reporter offers.
+type Verbosity int
+
+const (
+	VerbositySuccinct Verbosity = iota
+	VerbosityNormal
+	VerbosityVerbose
+)
+
+// StreamingReporter is the default reporter: it writes phase/metric lines
+// to Writer as they arrive instead of waiting for the run to finish. The
+// `--report` CLI bypass drives it on stdout; the TUI does not use it, and
+// viewBenchmark renders the report's metrics itself.
+type StreamingReporter struct {
+	Writer    io.Writer
+	Verbosity Verbosity
+}
+
+func (r *StreamingReporter) Begin(report *pb.BenchmarkReport) error {
+	if r.Verbosity == VerbositySuccinct {
+		return nil
+	}
+	_, err := fmt.Fprintf(r.Writer, "=== benchmark: %s (%s) ===\n", report.Device, report.Os)
+	return err
+}
+
+func (r *StreamingReporter) MetricEmitted(metric *pb.Metric) error {
+	switch r.Verbosity {
+	case VerbositySuccinct:
+		return nil
+	case VerbosityVerbose:
+		_, err := fmt.Fprintf(r.Writer, "  [metric] %-28s %10.2f %s\n", metric.Name, metric.Value, metric.Unit)
+		return err
+	default:
+		_, err := fmt.Fprintf(r.Writer, "  %s: %.2f %s\n", metric.Name, metric.Value, metric.Unit)
+		return err
+	}
+}
+
+func (r *StreamingReporter) End(report *pb.BenchmarkReport) error {
+	if r.Verbosity == VerbositySuccinct {
+		return nil
+	}
+	_, err := fmt.Fprintf(r.Writer, "=== done: %d metrics ===\n", len(reportMetrics(report)))
+	return err
+}
+
+// JSONReporter writes report as indented JSON to Writer, shaped like
+// reportSubmission so the same file a user exports here could also be
+// hand-posted to a prismreports aggregator.
+type JSONReporter struct {
+	Writer io.Writer
+}
+
+func (JSONReporter) Begin(*pb.BenchmarkReport) error { return nil }
+func (JSONReporter) MetricEmitted(*pb.Metric) error  { return nil }
+func (r JSONReporter) End(report *pb.BenchmarkReport) error {
+	enc := json.NewEncoder(r.Writer)
+	enc.SetIndent("", "  ")
+	return enc.Encode(reportSubmission{
+		Timestamp:       report.Timestamp,
+		Device:          report.Device,
+		Os:              report.Os,
+		PrismVersion:    report.PrismVersion,
+		IndexingMetrics: submissionMetrics(report.IndexingMetrics),
+		SearchMetrics:   submissionMetrics(report.SearchMetrics),
+		SystemMetrics:   submissionMetrics(report.SystemMetrics),
+	})
+}
+
+// JUnitReporter writes report as a JUnit XML testsuite, one testcase per
+// metric, every classname "prism.benchmark" - MetricEmitted gets no
+// category, so indexing, search and system metrics are not told apart by
+// classname. It is the shape CI systems (Jenkins,
+// GitLab, GitHub Actions' test-reporter) already know how to parse and gate
+// a build on, without prism having to speak any CI-specific format itself.
+type JUnitReporter struct {
+	Writer io.Writer
+
+	cases []junitTestCase
+}
+
+type junitTestSuite struct {
+	XMLName   xml.Name        `xml:"testsuite"`
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	TestCases []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	ClassName string  `xml:"classname,attr"`
+	Name      string  `xml:"name,attr"`
+	Value     float64 `xml:"value,attr"`
+	Unit      string  `xml:"unit,attr"`
+}
+
+func (r *JUnitReporter) Begin(*pb.BenchmarkReport) error { return nil }
+
+func (r *JUnitReporter) MetricEmitted(metric *pb.Metric) error {
+	r.cases = append(r.cases, junitTestCase{
+		ClassName: "prism.benchmark",
+		Name:      metric.Name,
+		Value:     metric.Value,
+		Unit:      metric.Unit,
+	})
+	return nil
+}
+
+func (r *JUnitReporter) End(report *pb.BenchmarkReport) error {
+	suite := junitTestSuite{
+		Name:      fmt.Sprintf("prism-benchmark-%s", report.PrismVersion),
+		Tests:     len(r.cases),
+		TestCases: r.cases,
+	}
+	if _, err := io.WriteString(r.Writer, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(r.Writer)
+	enc.Indent("", "  ")
+	return enc.Encode(suite)
+}
+
+// MarkdownReporter writes report as a Markdown table, meant for pasting
+// straight into a GitHub issue or PR description comparing runs.
+type MarkdownReporter struct {
+	Writer io.Writer
+
+	rows []string
+}
+
+func (r *MarkdownReporter) Begin(report *pb.BenchmarkReport) error {
+	_, err := fmt.Fprintf(r.Writer, "## Benchmark: %s / %s (prism %s)\n\n| Metric | Value | Unit |\n| --- | ---: | --- |\n",
+		report.Device, report.Os, report.PrismVersion)
+	return err
+}
+
+func (r *MarkdownReporter) MetricEmitted(metric *pb.Metric) error {
+	_, err := fmt.Fprintf(r.Writer, "| %s | %.2f | %s |\n", metric.Name, metric.Value, metric.Unit)
+	return err
+}
+
+func (r *MarkdownReporter) End(*pb.BenchmarkReport) error { return nil }
+
+// TabwriterReporter writes report as aligned columns via text/tabwriter,
+// for a benchmark comparison piped through a terminal.
+type TabwriterReporter struct {
+	Writer io.Writer
+
+	tw *tabwriter.Writer
+}
+
+func (r *TabwriterReporter) Begin(report *pb.BenchmarkReport) error {
+	r.tw = tabwriter.NewWriter(r.Writer, 0, 4, 2, ' ', 0)
+	_, err := fmt.Fprintf(r.tw, "%s\t%s (%s)\n", "BENCHMARK", report.Device, report.Os)
+	return err
+}
+
+func (r *TabwriterReporter) MetricEmitted(metric *pb.Metric) error {
+	_, err := fmt.Fprintf(r.tw, "%s\t%.2f %s\n", metric.Name, metric.Value, metric.Unit)
+	return err
+}
+
+func (r *TabwriterReporter) End(*pb.BenchmarkReport) error {
+	return r.tw.Flush()
+}
+
+// reportFormats lists the IDs viewBenchmark's [e] export picker cycles
+// through, in display order.
+var reportFormats = []string{"json", "junit", "markdown", "text"}
+
+// reportFormatExt maps a reportFormats ID to the file extension the
+// export picker's default filename uses.
+func reportFormatExt(format string) string {
+	switch format {
+	case "junit":
+		return "xml"
+	case "markdown":
+		return "md"
+	case "text":
+		return "txt"
+	default:
+		return format
+	}
+}
+
+// reporterForFormat builds the Reporter for one of reportFormats, writing
+// to w. An unrecognized format returns an error naming the valid ones; the
+// export picker only offers reportFormats, but `--report=fmt:path` passes
+// whatever fmt the user typed.
+func reporterForFormat(format string, w io.Writer) (Reporter, error) {
+	switch format {
+	case "json":
+		return JSONReporter{Writer: w}, nil
+	case "junit":
+		return &JUnitReporter{Writer: w}, nil
+	case "markdown":
+		return &MarkdownReporter{Writer: w}, nil
+	case "text":
+		return &TabwriterReporter{Writer: w}, nil
+	default:
+		return nil, fmt.Errorf("unknown report format %q (want one of %s)", format, strings.Join(reportFormats, ", "))
+	}
+}
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"os"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/ssh"
+	"github.com/charmbracelet/wish"
+	bm "github.com/charmbracelet/wish/bubbletea"
+	"github.com/charmbracelet/wish/logging"
+	"github.com/muesli/termenv"
+)
+
+const serveDefaultAddr = ":2323"
+
+// runServe starts `prism serve`, an SSH server that hands each connecting
+// client its own bubbletea program backed by a *lipgloss.Renderer tied to
+// that session's PTY, rather than the process-wide default renderer the
+// local TUI uses. This is what lets a truecolor kitty client, a 256-color
+// tmux client, and a NO_COLOR client each see appropriately downsampled
+// output from the same running daemon.
+func runServe(addr string) error {
+	if addr == "" {
+		addr = serveDefaultAddr
+	}
+
+	s, err := wish.NewServer(
+		wish.WithAddress(addr),
+		wish.WithMiddleware(
+			bm.Middleware(sessionHandler),
+			logging.Middleware(),
+		),
+	)
+	if err != nil {
+		return fmt.Errorf("prism serve: building ssh server: %w", err)
+	}
+
+	log.Printf("prism serve: listening on %s", addr)
+	return s.ListenAndServe()
+}
+
+// sessionHandler builds the bubbletea program for a single SSH session. It
+// starts from initialModel() and replaces m.styles with a Styles built from
+// this session's own lipgloss.Renderer; styles initialModel() sets on its
+// widgets (the text inputs' TextStyle, the spinner) stay on the default
+// renderer.
+func sessionHandler(sess ssh.Session) (tea.Model, []tea.ProgramOption) {
+	pty, _, _ := sess.Pty()
+
+	renderer := lipgloss.NewRenderer(sess, termenv.WithColorCache(true))
+	renderer.SetColorProfile(renderer.ColorProfile())
+
+	m := initialModel()
+	m.styles = NewStyles(renderer, activeTheme)
+	m.width = pty.Window.Width
+	m.height = pty.Window.Height
+
+	opts := []tea.ProgramOption{
+		tea.WithAltScreen(),
+		tea.WithInput(sess),
+		tea.WithOutput(sess),
+	}
+	return m, opts
+}
+
+// serveFromArgs handles `prism serve [addr]` before falling through to the
+// normal local TUI entry point in main().
+func serveFromArgs(args []string) (handled bool) {
+	if len(args) < 2 || args[1] != "serve" {
+		return false
+	}
+
+	addr := serveDefaultAddr
+	if len(args) > 2 {
+		addr = args[2]
+	}
+
+	if err := runServe(addr); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	return true
+}
@@ -1,81 +1,137 @@
 package main
 
 import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
 	"strings"
 
+	"github.com/BurntSushi/toml"
 	"github.com/charmbracelet/lipgloss"
-	"github.com/lucasb-eyer/go-colorful"
 )
 
-var (
-	// -- Colors --
-	// A "Cyberpunk/Premium" palette
-	primaryColor   = lipgloss.Color("#9D46FF") // Lighter Electric Purple (was #7D00FF)
-	secondaryColor = lipgloss.Color("#00E5FF") // Cyan / Electric Blue
-	accentColor    = lipgloss.Color("#FF00FF") // Neon Magenta
-
-	subtleColor  = lipgloss.Color("#666666")
-	textColor    = lipgloss.Color("#EEEEEE")
-	successColor = lipgloss.Color("#00FF99") // bright green
-	errorColor   = lipgloss.Color("#FF3333") // bright red
-	warningColor = lipgloss.Color("#FFD700") // gold
-
-	// -- Layout Styles --
-
-	// Main container
-	docStyle = lipgloss.NewStyle().Padding(1, 2)
-
-	// Panel Style (Subtle thin borders like Crush)
-	panelStyle = lipgloss.NewStyle().
-			Border(lipgloss.NormalBorder(), false, false, false, false). // No default borders
-			Padding(0, 1)
-
-	sidebarStyle = lipgloss.NewStyle().
-			Border(lipgloss.NormalBorder(), false, false, false, true).
-			BorderForeground(lipgloss.Color("#333333")).
-			Padding(0, 2)
-
-	separatorStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#222222")).
-			Margin(1, 0)
+// Theme collects every named color role plus the gradient stops used by
+// RenderGradientBanner, so a whole palette can be swapped at once instead of
+// editing package-level color vars by hand.
+type Theme struct {
+	Name string
+
+	Primary   lipgloss.AdaptiveColor
+	Secondary lipgloss.AdaptiveColor
+	Accent    lipgloss.AdaptiveColor
+	Subtle    lipgloss.AdaptiveColor
+	Text      lipgloss.AdaptiveColor
+	Success   lipgloss.AdaptiveColor
+	Error     lipgloss.AdaptiveColor
+	Warning   lipgloss.AdaptiveColor
+
+	// GradientStops are the hex colors RenderGradientBanner blends between,
+	// in order. Dark and light variants are kept separate since a gradient
+	// tuned for a black background usually washes out on white.
+	GradientStops [3]string
+}
 
-	// Textured background for headers
-	headerBoxStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#000000")).
-			Background(primaryColor).
-			Bold(true).
-			Padding(0, 1).
-			MarginBottom(1)
+// DefaultDark returns the original "Cyberpunk/Premium" palette this file
+// shipped with, used as the dark half of every AdaptiveColor below.
+func DefaultDark() Theme {
+	return Theme{
+		Name:          "cyberpunk-dark",
+		Primary:       lipgloss.AdaptiveColor{Light: "#7D00FF", Dark: "#9D46FF"},
+		Secondary:     lipgloss.AdaptiveColor{Light: "#008FA8", Dark: "#00E5FF"},
+		Accent:        lipgloss.AdaptiveColor{Light: "#A800A8", Dark: "#FF00FF"},
+		Subtle:        lipgloss.AdaptiveColor{Light: "#999999", Dark: "#666666"},
+		Text:          lipgloss.AdaptiveColor{Light: "#1A1A1A", Dark: "#EEEEEE"},
+		Success:       lipgloss.AdaptiveColor{Light: "#007A55", Dark: "#00FF99"},
+		Error:         lipgloss.AdaptiveColor{Light: "#B3261E", Dark: "#FF3333"},
+		Warning:       lipgloss.AdaptiveColor{Light: "#8A6D00", Dark: "#FFD700"},
+		GradientStops: [3]string{"#00E5FF", "#7D00FF", "#FF00FF"},
+	}
+}
 
-	keywordStyle = lipgloss.NewStyle().
-			Foreground(accentColor).
-			Italic(true)
+// DefaultLight returns a legible palette for light terminal backgrounds.
+// Only GradientStops differ from DefaultDark: the AdaptiveColor roles are
+// shared, so lipgloss still picks each role's light or dark half from the
+// detected background.
+func DefaultLight() Theme {
+	t := DefaultDark()
+	t.Name = "cyberpunk-light"
+	t.GradientStops = [3]string{"#008FA8", "#7D00FF", "#A800A8"}
+	return t
+}
 
-	asciiTexture = subtleStyle.Render(" //////////////////////////////////////////////////////// ")
+// Load reads a theme definition from a TOML or JSON file on disk, picking
+// the format from path's extension (".json" decodes as JSON; anything else,
+// including ".toml", decodes as TOML). The file mirrors the Theme fields,
+// with each color role given as a {light, dark} hex pair:
+//
+//	name = "custom"
+//	gradient_stops = ["#00E5FF", "#7D00FF", "#FF00FF"]
+//	[primary]
+//	light = "#7D00FF"
+//	dark  = "#9D46FF"
+//
+// or, equivalently, as JSON:
+//
+//	{"name": "custom", "primary": {"light": "#7D00FF", "dark": "#9D46FF"},
+//	 "gradient_stops": ["#00E5FF", "#7D00FF", "#FF00FF"]}
+func Load(path string) (Theme, error) {
+	var raw struct {
+		Name      string    `toml:"name" json:"name"`
+		Primary   pair      `toml:"primary" json:"primary"`
+		Secondary pair      `toml:"secondary" json:"secondary"`
+		Accent    pair      `toml:"accent" json:"accent"`
+		Subtle    pair      `toml:"subtle" json:"subtle"`
+		Text      pair      `toml:"text" json:"text"`
+		Success   pair      `toml:"success" json:"success"`
+		Error     pair      `toml:"error" json:"error"`
+		Warning   pair      `toml:"warning" json:"warning"`
+		Gradient  [3]string `toml:"gradient_stops" json:"gradient_stops"`
+	}
 
-	// -- Text Styles --
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Theme{}, fmt.Errorf("loading theme %q: %w", path, err)
+	}
 
-	titleStyle = lipgloss.NewStyle().
-			Foreground(secondaryColor).
-			Bold(true).
-			Padding(0, 1).
-			Background(lipgloss.Color("#333333"))
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		if err := json.Unmarshal(data, &raw); err != nil {
+			return Theme{}, fmt.Errorf("parsing theme %q: %w", path, err)
+		}
+	} else if _, err := toml.Decode(string(data), &raw); err != nil {
+		return Theme{}, fmt.Errorf("parsing theme %q: %w", path, err)
+	}
 
-	headerStyle = lipgloss.NewStyle().
-			Foreground(primaryColor).
-			Bold(true)
+	return Theme{
+		Name:          raw.Name,
+		Primary:       raw.Primary.adaptive(),
+		Secondary:     raw.Secondary.adaptive(),
+		Accent:        raw.Accent.adaptive(),
+		Subtle:        raw.Subtle.adaptive(),
+		Text:          raw.Text.adaptive(),
+		Success:       raw.Success.adaptive(),
+		Error:         raw.Error.adaptive(),
+		Warning:       raw.Warning.adaptive(),
+		GradientStops: raw.Gradient,
+	}, nil
+}
 
-	subtleStyle = lipgloss.NewStyle().
-			Foreground(subtleColor)
+type pair struct {
+	Light string `toml:"light" json:"light"`
+	Dark  string `toml:"dark" json:"dark"`
+}
 
-	successStyle = lipgloss.NewStyle().
-			Foreground(successColor).
-			Bold(true)
+func (p pair) adaptive() lipgloss.AdaptiveColor {
+	return lipgloss.AdaptiveColor{Light: p.Light, Dark: p.Dark}
+}
 
-	errorStyle = lipgloss.NewStyle().
-			Foreground(errorColor).
-			Bold(true)
+// activeTheme is the palette loadUserTheme (see theme_config.go) may replace
+// at startup. Every Styles value is built from it via NewStyles/defaultStyles;
+// the only other direct readers are themeGradient (the banner and shimmer
+// gradients) and sessionHandler in serve.go.
+var activeTheme = DefaultDark()
 
+var (
 	// -- Specific Component Styles --
 
 	bannerTxt = `
@@ -91,54 +147,6 @@ $$ |
 $$ |                                            
 \__|                                            `
 
-	// Stats
-	statLabelStyle = lipgloss.NewStyle().
-			Foreground(subtleColor).
-			Width(12)
-
-	statValueStyle = lipgloss.NewStyle().
-			Foreground(secondaryColor).
-			Bold(true)
-
-	// Inputs
-	inputPromptStyle = lipgloss.NewStyle().
-				Foreground(primaryColor).
-				Bold(true).
-				PaddingRight(1)
-
-	// Search Results
-	resultPathStyle = lipgloss.NewStyle().
-			Foreground(textColor)
-
-	resultScoreStyle = lipgloss.NewStyle().
-				Foreground(subtleColor).
-				Italic(true)
-
-	selectedResultStyle = lipgloss.NewStyle().
-				Border(lipgloss.NormalBorder(), false, false, false, true).
-				BorderForeground(accentColor).
-				Foreground(accentColor).
-				PaddingLeft(1).
-				Bold(true)
-
-	// Menu Items
-	selectedItemStyle = lipgloss.NewStyle().
-				Foreground(secondaryColor).
-				Bold(true)
-
-	// Loading Screen
-	loadingBoxStyle = lipgloss.NewStyle().
-			Border(lipgloss.DoubleBorder()).
-			BorderForeground(primaryColor).
-			Padding(1, 3).
-			Align(lipgloss.Center)
-
-	// Tiny Logs
-	logTextStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#444444")).
-			Italic(true).
-			PaddingTop(1)
-
 	// Tabs
 	activeTabBorder = lipgloss.Border{
 		Top:         "─",
@@ -161,58 +169,153 @@ $$ |
 		BottomLeft:  "┴",
 		BottomRight: "┴",
 	}
+)
 
-	tabStyle = lipgloss.NewStyle().
-			Border(tabBorder, true).
-			BorderForeground(subtleColor).
-			Padding(0, 1)
+// Styles is what every View() function renders through: one built from
+// lipgloss.DefaultRenderer() for the local TUI (see defaultStyles), and
+// one built per SSH session from that session's own *lipgloss.Renderer
+// (see sessionHandler in serve.go), so a truecolor kitty client, a
+// 256-color tmux client, and a NO_COLOR client each get output downsampled
+// to what they can actually display instead of the host's own profile.
+type Styles struct {
+	Theme Theme
+
+	Doc       lipgloss.Style
+	Panel     lipgloss.Style
+	Sidebar   lipgloss.Style
+	Separator lipgloss.Style
+
+	Header    lipgloss.Style
+	HeaderBox lipgloss.Style
+	Title     lipgloss.Style
+	Keyword   lipgloss.Style
+
+	Subtle  lipgloss.Style
+	Success lipgloss.Style
+	Error   lipgloss.Style
+	Warning lipgloss.Style
+
+	StatLabel lipgloss.Style
+	StatValue lipgloss.Style
+
+	InputPrompt  lipgloss.Style
+	ResultPath   lipgloss.Style
+	ResultScore  lipgloss.Style
+	ResultHit    lipgloss.Style
+	SelectedItem lipgloss.Style
+	LoadingBox   lipgloss.Style
+	LogText      lipgloss.Style
+
+	Tab       lipgloss.Style
+	ActiveTab lipgloss.Style
+	TabGap    lipgloss.Style
+}
 
-	activeTabStyle = tabStyle.Copy().
-			Border(activeTabBorder, true).
-			BorderForeground(primaryColor).
-			Foreground(primaryColor).
-			Bold(true)
+// NewStyles builds a Styles bound to r, so every color and border resolves
+// through r's color profile instead of the process-wide default renderer.
+func NewStyles(r *lipgloss.Renderer, theme Theme) Styles {
+	tab := r.NewStyle().
+		Border(tabBorder, true).
+		BorderForeground(theme.Subtle).
+		Padding(0, 1)
+
+	return Styles{
+		Theme: theme,
+
+		Doc: r.NewStyle().Padding(1, 2),
+		Panel: r.NewStyle().
+			Border(lipgloss.NormalBorder(), false, false, false, false).
+			Padding(0, 1),
+		Sidebar: r.NewStyle().
+			Border(lipgloss.NormalBorder(), false, false, false, true).
+			BorderForeground(lipgloss.Color("#333333")).
+			Padding(0, 2),
+		Separator: r.NewStyle().
+			Foreground(lipgloss.Color("#222222")).
+			Margin(1, 0),
+
+		Header: r.NewStyle().
+			Foreground(theme.Primary).
+			Bold(true),
+		HeaderBox: r.NewStyle().
+			Foreground(lipgloss.Color("#000000")).
+			Background(theme.Primary).
+			Bold(true).
+			Padding(0, 1).
+			MarginBottom(1),
+		Title: r.NewStyle().
+			Foreground(theme.Secondary).
+			Bold(true).
+			Padding(0, 1).
+			Background(lipgloss.Color("#333333")),
+		Keyword: r.NewStyle().
+			Foreground(theme.Accent).
+			Italic(true),
+
+		Subtle:  r.NewStyle().Foreground(theme.Subtle),
+		Success: r.NewStyle().Foreground(theme.Success).Bold(true),
+		Error:   r.NewStyle().Foreground(theme.Error).Bold(true),
+		Warning: r.NewStyle().Foreground(theme.Warning).Bold(true),
+
+		StatLabel: r.NewStyle().Foreground(theme.Subtle).Width(12),
+		StatValue: r.NewStyle().Foreground(theme.Secondary).Bold(true),
+
+		InputPrompt: r.NewStyle().
+			Foreground(theme.Primary).
+			Bold(true).
+			PaddingRight(1),
+		ResultPath:  r.NewStyle().Foreground(theme.Text),
+		ResultScore: r.NewStyle().Foreground(theme.Subtle).Italic(true),
+		ResultHit: r.NewStyle().
+			Border(lipgloss.NormalBorder(), false, false, false, true).
+			BorderForeground(theme.Accent).
+			Foreground(theme.Accent).
+			PaddingLeft(1).
+			Bold(true),
+		SelectedItem: r.NewStyle().Foreground(theme.Secondary).Bold(true),
+		LoadingBox: r.NewStyle().
+			Border(lipgloss.DoubleBorder()).
+			BorderForeground(theme.Primary).
+			Padding(1, 3).
+			Align(lipgloss.Center),
+		LogText: r.NewStyle().
+			Foreground(lipgloss.Color("#444444")).
+			Italic(true).
+			PaddingTop(1),
 
-	tabGapStyle = tabStyle.Copy().
+		Tab: tab,
+		ActiveTab: r.NewStyle().
+			Border(activeTabBorder, true).
+			BorderForeground(theme.Primary).
+			Foreground(theme.Primary).
+			Bold(true).
+			Padding(0, 1),
+		TabGap: tab.Copy().
 			BorderTop(false).
 			BorderLeft(false).
 			BorderRight(false).
-			BorderBottom(true)
-)
-
-// RenderGradientBanner renders the banner with a diagonal gradient
-func RenderGradientBanner() string {
-	lines := strings.Split(bannerTxt, "\n")
-	var out strings.Builder
+			BorderBottom(true),
+	}
+}
 
-	// Shimmering Palette
-	c1, _ := colorful.Hex("#00E5FF") // Electric Blue
-	c2, _ := colorful.Hex("#7D00FF") // Deep Indigo
-	c3, _ := colorful.Hex("#FF00FF") // Neon Magenta
+// defaultStyles is the Styles bound to lipgloss's default, process-wide
+// renderer — what the local (non-SSH) TUI uses.
+func defaultStyles() Styles {
+	return NewStyles(lipgloss.DefaultRenderer(), activeTheme)
+}
 
-	for y, line := range lines {
-		if strings.TrimSpace(line) == "" && y == 0 {
-			continue
-		}
+// asciiTexture renders the footer's decorative rule in s's Subtle style.
+func (s Styles) asciiTexture() string {
+	return s.Subtle.Render(" //////////////////////////////////////////////////////// ")
+}
 
-		for x, char := range line {
-			// Diagonal T (sum of X and Y normalized)
-			// Adjust multipliers to stretch/shrink gradient
-			normX := float64(x) / 40.0
-			normY := float64(y) / float64(len(lines))
-			t := (normX + normY) / 2.0
-
-			var c colorful.Color
-			if t < 0.5 {
-				c = c1.BlendLuv(c2, t*2)
-			} else {
-				c = c2.BlendLuv(c3, (t-0.5)*2)
-			}
-
-			style := lipgloss.NewStyle().Foreground(lipgloss.Color(c.Hex())).Bold(true)
-			out.WriteString(style.Render(string(char)))
-		}
-		out.WriteString("\n")
+// RenderGradientBanner renders the banner with a diagonal gradient, blending
+// between activeTheme's GradientStops via the reusable GradientColor type
+// rather than hand-rolled BlendLuv calls.
+func RenderGradientBanner() string {
+	lines := strings.Split(bannerTxt, "\n")
+	if len(lines) > 0 && strings.TrimSpace(lines[0]) == "" {
+		lines = lines[1:]
 	}
-	return out.String()
+	return themeGradient(40, GradientDiagonal).RenderBlock(strings.Join(lines, "\n")) + "\n"
 }
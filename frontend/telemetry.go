@@ -0,0 +1,210 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	pb "github.com/sjanney/prism/proto"
+)
+
+// defaultTelemetryEndpoint is the community-run cmd/prismreports instance;
+// users who want their own aggregator (or no submission at all) override it
+// in telemetry.json.
+const defaultTelemetryEndpoint = "https://reports.prism.dev"
+
+// TelemetryConfig is the user's opt-in preference for submitting benchmark
+// reports to a prismreports aggregator, persisted at
+// ~/.config/prism/telemetry.json so it survives restarts the same way
+// profiles.toml and plugins do.
+type TelemetryConfig struct {
+	Enabled  bool   `json:"enabled"`
+	Endpoint string `json:"endpoint"`
+}
+
+// defaultTelemetryPath returns ~/.config/prism/telemetry.json (honoring
+// XDG_CONFIG_HOME via os.UserConfigDir, same as defaultProfilesPath).
+func defaultTelemetryPath() string {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "prism", "telemetry.json")
+}
+
+// loadedTelemetryConfig reads telemetry.json, falling back to a disabled
+// config pointing at defaultTelemetryEndpoint if it's missing or fails to
+// parse - telemetry is opt-in, so any error here must fail closed.
+func loadedTelemetryConfig() TelemetryConfig {
+	cfg, err := loadTelemetryConfig(defaultTelemetryPath())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "prism: %v; telemetry stays disabled\n", err)
+	}
+	if cfg.Endpoint == "" {
+		cfg.Endpoint = defaultTelemetryEndpoint
+	}
+	return cfg
+}
+
+// loadTelemetryConfig reads path. A missing file is not an error - it just
+// means the user has never opted in, same as a missing profiles.toml.
+func loadTelemetryConfig(path string) (TelemetryConfig, error) {
+	if path == "" {
+		return TelemetryConfig{}, nil
+	}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return TelemetryConfig{}, nil
+	}
+	if err != nil {
+		return TelemetryConfig{}, fmt.Errorf("reading telemetry config: %w", err)
+	}
+	var cfg TelemetryConfig
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return TelemetryConfig{}, fmt.Errorf("parsing telemetry config: %w", err)
+	}
+	return cfg, nil
+}
+
+// saveTelemetryConfig persists cfg to defaultTelemetryPath, creating the
+// ~/.config/prism directory if needed.
+func saveTelemetryConfig(cfg TelemetryConfig) error {
+	path := defaultTelemetryPath()
+	if path == "" {
+		return fmt.Errorf("resolving config dir: no user config dir available")
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("creating config dir: %w", err)
+	}
+	data, err := json.MarshalIndent(cfg, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o644)
+}
+
+// telemetrySubmittedMsg carries the result of one submitBenchmarkReportCmd
+// POST: either empty (success) or a non-nil err.
+type telemetrySubmittedMsg struct {
+	err error
+}
+
+// submitBenchmarkReportCmd POSTs report as JSON to endpoint's /reports
+// route. The server-side schema (cmd/prismreports) is deliberately a plain
+// anonymous struct rather than pb.BenchmarkReport itself, so a submission
+// never leaks fields (hostnames, dataset paths) the proto might grow later
+// that users didn't consent to share.
+func submitBenchmarkReportCmd(endpoint string, report *pb.BenchmarkReport) tea.Cmd {
+	return func() tea.Msg {
+		body, err := json.Marshal(reportSubmission{
+			Timestamp:       report.Timestamp,
+			Device:          report.Device,
+			Os:              report.Os,
+			PrismVersion:    report.PrismVersion,
+			IndexingMetrics: submissionMetrics(report.IndexingMetrics),
+			SearchMetrics:   submissionMetrics(report.SearchMetrics),
+			SystemMetrics:   submissionMetrics(report.SystemMetrics),
+		})
+		if err != nil {
+			return telemetrySubmittedMsg{err: err}
+		}
+
+		req, err := http.NewRequest(http.MethodPost, endpoint+"/reports", bytes.NewReader(body))
+		if err != nil {
+			return telemetrySubmittedMsg{err: err}
+		}
+		req.Header.Set("Content-Type", "application/json")
+
+		client := &http.Client{Timeout: 10 * time.Second}
+		resp, err := client.Do(req)
+		if err != nil {
+			return telemetrySubmittedMsg{err: err}
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode >= 300 {
+			return telemetrySubmittedMsg{err: fmt.Errorf("reports endpoint returned %s", resp.Status)}
+		}
+		return telemetrySubmittedMsg{}
+	}
+}
+
+// reportSubmission is the wire shape POSTed to a prismreports aggregator's
+// /reports route - see cmd/prismreports/store.go for the matching schema.
+type reportSubmission struct {
+	Timestamp       string             `json:"timestamp"`
+	Device          string             `json:"device"`
+	Os              string             `json:"os"`
+	PrismVersion    string             `json:"prism_version"`
+	IndexingMetrics []submissionMetric `json:"indexing_metrics"`
+	SearchMetrics   []submissionMetric `json:"search_metrics"`
+	SystemMetrics   []submissionMetric `json:"system_metrics"`
+}
+
+type submissionMetric struct {
+	Name  string  `json:"name"`
+	Value float64 `json:"value"`
+	Unit  string  `json:"unit"`
+}
+
+func submissionMetrics(in []*pb.Metric) []submissionMetric {
+	out := make([]submissionMetric, 0, len(in))
+	for _, m := range in {
+		out = append(out, submissionMetric{Name: m.Name, Value: m.Value, Unit: m.Unit})
+	}
+	return out
+}
+
+// CommunitySummary is one metric's aggregate stats from a prismreports
+// GET /summary response: every known prism_version combined via a
+// sample-weighted average, optionally scoped to the device class the
+// request asked about (DeviceClass echoes back what was requested, empty
+// if the query didn't scope to one).
+type CommunitySummary struct {
+	Metric      string  `json:"metric"`
+	DeviceClass string  `json:"device_class,omitempty"`
+	SampleCount int     `json:"sample_count"`
+	Median      float64 `json:"median"`
+	P95         float64 `json:"p95"`
+	Unit        string  `json:"unit"`
+}
+
+// communitySummaryMsg carries the result of one fetchCommunitySummaryCmd
+// GET: either a populated CommunitySummary, or a non-nil err.
+type communitySummaryMsg struct {
+	summary CommunitySummary
+	err     error
+}
+
+// fetchCommunitySummaryCmd GETs endpoint's /summary route for metric,
+// scoped to deviceClass if non-empty, returning the community's median/p95
+// for comparison against the user's own last benchmark run on that same
+// kind of device.
+func fetchCommunitySummaryCmd(endpoint, metric, deviceClass string) tea.Cmd {
+	return func() tea.Msg {
+		reqURL := endpoint + "/summary?metric=" + url.QueryEscape(metric)
+		if deviceClass != "" {
+			reqURL += "&device_class=" + url.QueryEscape(deviceClass)
+		}
+		client := &http.Client{Timeout: 10 * time.Second}
+		resp, err := client.Get(reqURL)
+		if err != nil {
+			return communitySummaryMsg{err: err}
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode >= 300 {
+			return communitySummaryMsg{err: fmt.Errorf("summary endpoint returned %s", resp.Status)}
+		}
+		var summary CommunitySummary
+		if err := json.NewDecoder(resp.Body).Decode(&summary); err != nil {
+			return communitySummaryMsg{err: err}
+		}
+		return communitySummaryMsg{summary: summary}
+	}
+}
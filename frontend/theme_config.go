@@ -0,0 +1,64 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// loadUserTheme resolves the theme the process should start with: an
+// explicit --theme <path>/--theme=<path> argument, the PRISM_THEME
+// environment variable, or the first theme.toml/theme.json found under the
+// user's config directory, in that priority order. If none is set, or the
+// resolved file fails to load, it falls back to DefaultDark so a broken
+// config never prevents the TUI from starting.
+func loadUserTheme(args []string) Theme {
+	path := themePathFromArgs(args)
+	if path == "" {
+		path = os.Getenv("PRISM_THEME")
+	}
+	if path == "" {
+		path = firstExistingThemeConfig()
+	}
+	if path == "" {
+		return DefaultDark()
+	}
+
+	theme, err := Load(path)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "prism: %v; falling back to default theme\n", err)
+		return DefaultDark()
+	}
+	return theme
+}
+
+// themePathFromArgs scans args for a --theme <path> or --theme=<path> flag.
+func themePathFromArgs(args []string) string {
+	for i, arg := range args {
+		if arg == "--theme" && i+1 < len(args) {
+			return args[i+1]
+		}
+		if rest, ok := strings.CutPrefix(arg, "--theme="); ok {
+			return rest
+		}
+	}
+	return ""
+}
+
+// firstExistingThemeConfig returns the first of
+// $XDG_CONFIG_HOME/prism/theme.toml and theme.json that exists on disk, or
+// "" if neither does.
+func firstExistingThemeConfig() string {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+	for _, name := range []string{"theme.toml", "theme.json"} {
+		p := filepath.Join(dir, "prism", name)
+		if _, err := os.Stat(p); err == nil {
+			return p
+		}
+	}
+	return ""
+}
@@ -0,0 +1,56 @@
+package main
+
+import "container/list"
+
+// thumbnailCacheSize caps how many rendered thumbnails are kept in memory,
+// large enough to cover a full screen of results plus scroll-ahead without
+// growing unbounded as the user pages through a big result set.
+const thumbnailCacheSize = 64
+
+// thumbnailCache is a small LRU of path+mtime -> already-rendered terminal
+// escape sequence, so moving the cursor back over a result it's already
+// visited doesn't re-fetch or re-render its thumbnail.
+type thumbnailCache struct {
+	cap   int
+	order *list.List
+	items map[string]*list.Element
+}
+
+type thumbnailCacheEntry struct {
+	key      string
+	rendered string
+}
+
+func newThumbnailCache() *thumbnailCache {
+	return &thumbnailCache{
+		cap:   thumbnailCacheSize,
+		order: list.New(),
+		items: make(map[string]*list.Element),
+	}
+}
+
+func (c *thumbnailCache) get(key string) (string, bool) {
+	el, ok := c.items[key]
+	if !ok {
+		return "", false
+	}
+	c.order.MoveToFront(el)
+	return el.Value.(*thumbnailCacheEntry).rendered, true
+}
+
+func (c *thumbnailCache) put(key, rendered string) {
+	if el, ok := c.items[key]; ok {
+		el.Value.(*thumbnailCacheEntry).rendered = rendered
+		c.order.MoveToFront(el)
+		return
+	}
+	el := c.order.PushFront(&thumbnailCacheEntry{key: key, rendered: rendered})
+	c.items[key] = el
+	if c.order.Len() > c.cap {
+		oldest := c.order.Back()
+		if oldest != nil {
+			c.order.Remove(oldest)
+			delete(c.items, oldest.Value.(*thumbnailCacheEntry).key)
+		}
+	}
+}
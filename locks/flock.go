@@ -0,0 +1,69 @@
+package locks
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"syscall"
+	"time"
+)
+
+// stealPollInterval is how often flock retries a non-blocking lock while
+// steal is waiting for the current holder to exit.
+const stealPollInterval = 200 * time.Millisecond
+
+// flock takes an exclusive flock(2) on f. Without steal it's a single
+// non-blocking attempt. With steal it polls a non-blocking lock instead of
+// making one blocking LOCK_EX syscall, because a blocking syscall can't be
+// interrupted by ctx going done - polling is what lets Steal(ctx) honor a
+// caller's timeout instead of hanging past it.
+func flock(ctx context.Context, f *os.File, steal bool) error {
+	how := syscall.LOCK_EX | syscall.LOCK_NB
+	if !steal {
+		return syscall.Flock(int(f.Fd()), how)
+	}
+
+	ticker := time.NewTicker(stealPollInterval)
+	defer ticker.Stop()
+	for {
+		err := syscall.Flock(int(f.Fd()), how)
+		if err != syscall.EWOULDBLOCK {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+func funlock(f *os.File) error {
+	return syscall.Flock(int(f.Fd()), syscall.LOCK_UN)
+}
+
+// writeHolder truncates f and writes holder as JSON, so a process that
+// fails to acquire the lock can still read who holds it.
+func writeHolder(f *os.File, holder Holder) error {
+	if err := f.Truncate(0); err != nil {
+		return err
+	}
+	if _, err := f.Seek(0, 0); err != nil {
+		return err
+	}
+	enc := json.NewEncoder(f)
+	return enc.Encode(holder)
+}
+
+// readHolder best-efforts reading the Holder left behind by whoever
+// currently holds path's lock file. A read failure just means the caller
+// ends up with a zero Holder, which is a stale-but-harmless worst case.
+func readHolder(path string) Holder {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Holder{}
+	}
+	var holder Holder
+	_ = json.Unmarshal(data, &holder)
+	return holder
+}
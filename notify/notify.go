@@ -0,0 +1,235 @@
+// Package notify turns prism's activity into a structured event stream that
+// can be piped to external systems instead of only showing up in the TUI's
+// own notification sidebar: stdout JSON lines, a local unix-socket tail, or
+// an authenticated HTTP webhook.
+package notify
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+)
+
+// Severity mirrors the TUI's own NotificationType ordering so callers can
+// convert between the two without a lookup table.
+type Severity int
+
+const (
+	SeverityInfo Severity = iota
+	SeveritySuccess
+	SeverityWarning
+	SeverityError
+)
+
+func (s Severity) String() string {
+	switch s {
+	case SeveritySuccess:
+		return "success"
+	case SeverityWarning:
+		return "warning"
+	case SeverityError:
+		return "error"
+	default:
+		return "info"
+	}
+}
+
+// MarshalJSON renders a Severity as its name rather than its ordinal, so
+// sinks downstream (Splunk, Datadog, Slack) don't have to know the enum.
+func (s Severity) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.String())
+}
+
+// Event is one thing prism did or observed: a state transition, an index
+// progress tick, a search result batch, a benchmark phase, a cloud-save
+// outcome, and so on. CorrelationID ties a run of related events together
+// (e.g. every tick of one index job shares its job ID).
+type Event struct {
+	Kind          string            `json:"kind"`
+	Severity      Severity          `json:"severity"`
+	Timestamp     time.Time         `json:"timestamp"`
+	CorrelationID string            `json:"correlation_id,omitempty"`
+	Attributes    map[string]string `json:"attributes,omitempty"`
+}
+
+// Sink is one destination an Event can be fanned out to.
+type Sink interface {
+	Emit(Event) error
+}
+
+// busQueueSize bounds how many Events Emit can get ahead of a slow sink
+// (e.g. WebhookSink stalled on a dead endpoint) before Emit starts
+// dropping events instead of blocking its caller.
+const busQueueSize = 256
+
+// Bus fans every Emit out to all configured Sinks from a single background
+// goroutine, so a sink that errors - or just runs slowly, like a webhook
+// round trip - can't block the caller. A sink error is logged, not fatal,
+// so one bad webhook can't block stdout or the others.
+type Bus struct {
+	mu    sync.Mutex
+	sinks []Sink
+
+	events chan Event
+}
+
+// NewBus builds a Bus over the given Sinks, any of which may be nil to
+// build incrementally, and starts its fan-out goroutine.
+func NewBus(sinks ...Sink) *Bus {
+	b := &Bus{events: make(chan Event, busQueueSize)}
+	for _, s := range sinks {
+		if s != nil {
+			b.sinks = append(b.sinks, s)
+		}
+	}
+	go b.run()
+	return b
+}
+
+// run drains events and fans each one out to every sink, off the caller's
+// goroutine - this is what lets Emit return immediately even when a sink
+// is slow or unreachable.
+func (b *Bus) run() {
+	for e := range b.events {
+		b.mu.Lock()
+		sinks := b.sinks
+		b.mu.Unlock()
+
+		for _, s := range sinks {
+			if err := s.Emit(e); err != nil {
+				fmt.Fprintf(os.Stderr, "prism: notify sink error: %v\n", err)
+			}
+		}
+	}
+}
+
+// Emit stamps e.Timestamp if unset and queues it for async fan-out,
+// without blocking the caller - callers include bubbletea's single-
+// goroutine Update loop, which a slow sink must never stall. If the queue
+// is full (a sink has fallen far behind), the event is dropped rather than
+// blocking.
+func (b *Bus) Emit(e Event) {
+	if e.Timestamp.IsZero() {
+		e.Timestamp = time.Now()
+	}
+	select {
+	case b.events <- e:
+	default:
+		fmt.Fprintf(os.Stderr, "prism: notify bus queue full, dropping %s event\n", e.Kind)
+	}
+}
+
+// Close stops the Bus's fan-out goroutine once any already-queued events
+// have been delivered. Safe to call on a Bus whose goroutine has nothing
+// left to drain.
+func (b *Bus) Close() {
+	close(b.events)
+}
+
+// StdoutSink writes each Event as a single JSON line to stdout.
+type StdoutSink struct{}
+
+func (StdoutSink) Emit(e Event) error {
+	return json.NewEncoder(os.Stdout).Encode(e)
+}
+
+// UnixSocketSink broadcasts each Event as a newline-delimited JSON line to
+// every client connected to a unix socket at Path - the same "tail -f" shape
+// as Docker's events socket, readable with `socat - UNIX-CONNECT:path`.
+type UnixSocketSink struct {
+	path string
+
+	mu      sync.Mutex
+	clients map[net.Conn]struct{}
+}
+
+// NewUnixSocketSink listens on path, removing any stale socket file left
+// behind by a previous run first.
+func NewUnixSocketSink(path string) (*UnixSocketSink, error) {
+	_ = os.Remove(path)
+	ln, err := net.Listen("unix", path)
+	if err != nil {
+		return nil, fmt.Errorf("listening on %s: %w", path, err)
+	}
+	s := &UnixSocketSink{path: path, clients: make(map[net.Conn]struct{})}
+	go s.accept(ln)
+	return s, nil
+}
+
+func (s *UnixSocketSink) accept(ln net.Listener) {
+	for {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		s.mu.Lock()
+		s.clients[conn] = struct{}{}
+		s.mu.Unlock()
+	}
+}
+
+func (s *UnixSocketSink) Emit(e Event) error {
+	line, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	line = append(line, '\n')
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for conn := range s.clients {
+		if _, err := conn.Write(line); err != nil {
+			conn.Close()
+			delete(s.clients, conn)
+		}
+	}
+	return nil
+}
+
+// WebhookSink POSTs each Event as JSON to URL, attaching AuthToken as a
+// bearer token the way MinIO's Splunk-compatible webhook target expects it.
+type WebhookSink struct {
+	URL       string
+	AuthToken string
+	Client    *http.Client
+}
+
+// NewWebhookSink builds a WebhookSink with a short request timeout so a
+// slow/unreachable endpoint can't stall the event bus.
+func NewWebhookSink(url, authToken string) *WebhookSink {
+	return &WebhookSink{URL: url, AuthToken: authToken, Client: &http.Client{Timeout: 5 * time.Second}}
+}
+
+func (w *WebhookSink) Emit(e Event) error {
+	body, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequest(http.MethodPost, w.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if w.AuthToken != "" {
+		req.Header.Set("Authorization", "Bearer "+w.AuthToken)
+	}
+
+	client := w.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook %s returned %s", w.URL, resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,131 @@
+// Package plugins lets third parties add new dashboard panels to the prism
+// TUI without patching main: a Panel is a small bubbletea sub-model, and a
+// Registry collects the ones found in ~/.config/prism/plugins at startup.
+package plugins
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"plugin"
+	"sort"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Panel is a dashboard entry a plugin contributes. It mirrors bubbletea's
+// own Model interface, plus the bits the dashboard menu and help line need:
+// a stable ID to key the cache on, a Title for the COMMAND MODULES menu,
+// and KeyBindings to show in the footer while the panel is active.
+type Panel interface {
+	ID() string
+	Title() string
+	Init() tea.Cmd
+	Update(tea.Msg) (Panel, tea.Cmd)
+	View() string
+	KeyBindings() []string
+}
+
+// Registry holds the Panels loaded for this run, keyed by ID, in load order
+// so menu placement is stable across restarts.
+type Registry struct {
+	panels map[string]Panel
+	order  []string
+}
+
+// NewRegistry returns an empty Registry. Panels are added with Register or
+// in bulk via LoadDir.
+func NewRegistry() *Registry {
+	return &Registry{panels: make(map[string]Panel)}
+}
+
+// Register adds p to the registry. It's an error to register two Panels
+// with the same ID.
+func (r *Registry) Register(p Panel) error {
+	if _, exists := r.panels[p.ID()]; exists {
+		return fmt.Errorf("plugin %q already registered", p.ID())
+	}
+	r.panels[p.ID()] = p
+	r.order = append(r.order, p.ID())
+	return nil
+}
+
+// Panels returns the registered Panels in registration order.
+func (r *Registry) Panels() []Panel {
+	out := make([]Panel, 0, len(r.order))
+	for _, id := range r.order {
+		out = append(out, r.panels[id])
+	}
+	return out
+}
+
+// ByTitle returns the Panel whose Title matches title, for dispatching a
+// dashboard menu selection back to the plugin that contributed it.
+func (r *Registry) ByTitle(title string) (Panel, bool) {
+	for _, id := range r.order {
+		if p := r.panels[id]; p.Title() == title {
+			return p, true
+		}
+	}
+	return nil, false
+}
+
+// Factory is the signature of the function every plugin .so must export
+// under the name "Panel": a zero-arg constructor for the Panel it provides.
+// LoadDir asserts the looked-up symbol to func() Panel, so an exported var
+// named Panel (which Lookup returns as a pointer) is not accepted.
+type Factory func() Panel
+
+// LoadDir opens every *.so in dir and registers the Panel its exported
+// "Panel" factory constructs. A missing dir isn't an error - it just means
+// no plugins have been installed yet, same as loadProfiles treats a missing
+// profiles.toml. A single plugin that fails to open, is missing its Panel
+// factory, or fails to register doesn't abort the rest - it's collected
+// into the returned error (via errors.Join) while every other .so still
+// gets loaded, the same tolerance rebuiltEventBus shows a sink that fails
+// to construct.
+func LoadDir(dir string) (*Registry, error) {
+	r := NewRegistry()
+
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return r, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading plugin dir: %w", err)
+	}
+
+	var names []string
+	for _, e := range entries {
+		if !e.IsDir() && filepath.Ext(e.Name()) == ".so" {
+			names = append(names, e.Name())
+		}
+	}
+	sort.Strings(names)
+
+	var errs []error
+	for _, name := range names {
+		path := filepath.Join(dir, name)
+		p, err := plugin.Open(path)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("opening plugin %s: %w", name, err))
+			continue
+		}
+		sym, err := p.Lookup("Panel")
+		if err != nil {
+			errs = append(errs, fmt.Errorf("plugin %s: missing exported Panel factory: %w", name, err))
+			continue
+		}
+		factory, ok := sym.(func() Panel)
+		if !ok {
+			errs = append(errs, fmt.Errorf("plugin %s: Panel symbol has the wrong type", name))
+			continue
+		}
+		if err := r.Register(factory()); err != nil {
+			errs = append(errs, fmt.Errorf("plugin %s: %w", name, err))
+			continue
+		}
+	}
+	return r, errors.Join(errs...)
+}